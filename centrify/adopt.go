@@ -0,0 +1,42 @@
+package centrify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func getAdoptExistingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Take over existing object with the same name instead of failing to create a duplicate",
+	}
+}
+
+// adoptExisting takes over an existing object found by lookup and reconciles its attributes with configuration
+// by running resource update. It returns false if there is no such object so that it is created as usual.
+// If reconciliation fails the object isn't kept in state, as it wasn't created by Terraform it must neither be
+// tainted and replaced by next apply nor deleted by rollback_on_failed_create.
+func adoptExisting(d *schema.ResourceData, m interface{}, kind string, lookup func() (string, error),
+	update func(*schema.ResourceData, interface{}) error) (bool, error) {
+	id, err := lookup()
+	if err != nil {
+		// Lookup by name of golang-sdk reports that it found no object
+		if isNotFound(err) || strings.Contains(strings.ToLower(err.Error()), "query returns 0 object") {
+			return false, nil
+		}
+		return false, fmt.Errorf(" Error looking up existing %s: %v", kind, err)
+	}
+
+	logFor(m).Infof("Adopting existing %s: %s", kind, id)
+	d.SetId(id)
+	if err := update(d, m); err != nil {
+		d.SetId("")
+		return true, fmt.Errorf(" Error reconciling existing %s %s: %v", kind, id, err)
+	}
+
+	return true, nil
+}
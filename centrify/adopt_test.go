@@ -0,0 +1,74 @@
+package centrify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
+)
+
+func testRoleCreate(t *testing.T, server *mocktenant.Server, adopt bool) (*schema.ResourceData, error) {
+	meta := testMockClient(t, testMockTokenConfig(server))
+
+	r := Provider().ResourcesMap["centrify_role"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "Existing Role",
+		"description":    "Managed by Terraform",
+		"adopt_existing": adopt,
+	})
+	d.MarkNewResource()
+	return d, r.Create(d, meta)
+}
+
+func testSeedRole(server *mocktenant.Server) string {
+	return server.Insert("Role", map[string]interface{}{
+		"Name":        "Existing Role",
+		"Description": "Created by hand",
+		"Members":     []interface{}{},
+		"Rights":      map[string]interface{}{},
+	})
+}
+
+func TestAdoptExisting(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	id := testSeedRole(server)
+
+	d, err := testRoleCreate(t, server, true)
+	if err != nil {
+		t.Fatalf("Expected existing role to be adopted, got %v", err)
+	}
+	if d.Id() != id {
+		t.Errorf("Expected ID of existing role %s, got %s", id, d.Id())
+	}
+	rows := server.Rows("Role")
+	if len(rows) != 1 {
+		t.Fatalf("Expected no duplicate role to be created, found %d roles", len(rows))
+	}
+	if rows[0]["Description"] != "Managed by Terraform" {
+		t.Errorf("Expected existing role to be reconciled with configuration, description is %v", rows[0]["Description"])
+	}
+}
+
+func TestAdoptExisting_notFound(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	d, err := testRoleCreate(t, server, true)
+	if err != nil {
+		t.Fatalf("Expected role to be created when there is nothing to adopt, got %v", err)
+	}
+	if d.Id() == "" || len(server.Rows("Role")) != 1 {
+		t.Error("Expected new role to be created")
+	}
+}
+
+func TestAdoptExisting_disabled(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	testSeedRole(server)
+
+	if _, err := testRoleCreate(t, server, false); err == nil {
+		t.Error("Expected duplicate role name to fail without adopt_existing")
+	}
+}
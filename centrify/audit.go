@@ -0,0 +1,177 @@
+package centrify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// auditEntry - audit journal record of a mutating API call. Only attribute names are recorded, never their values.
+type auditEntry struct {
+	Timestamp         string   `json:"timestamp"`
+	ResourceType      string   `json:"resource_type,omitempty"`
+	ObjectID          string   `json:"object_id,omitempty"`
+	Operation         string   `json:"operation,omitempty"`
+	API               string   `json:"api"`
+	ChangedAttributes []string `json:"changed_attributes,omitempty"`
+	Result            string   `json:"result"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// auditJournal appends JSON lines to audit journal file
+type auditJournal struct {
+	mu       sync.Mutex
+	path     string
+	redactor *redactor
+}
+
+func newAuditJournal(journalPath string, r *redactor) (*auditJournal, error) {
+	// Make sure the journal can be written before any change is made
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf(" Failed to open audit journal %s: %v", journalPath, err)
+	}
+	f.Close()
+
+	return &auditJournal{path: journalPath, redactor: r}, nil
+}
+
+// Write appends entry to the journal. File is opened for every entry so that journal can be rotated
+// while provider is running.
+func (j *auditJournal) Write(entry auditEntry) error {
+	if j.redactor != nil {
+		entry.Error = j.redactor.Redact(entry.Error)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// auditTransport records every API call that modifies tenant data in audit journal
+type auditTransport struct {
+	base    http.RoundTripper
+	journal *auditJournal
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isIdempotentRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(withRequestBody(req, body))
+
+	entry := auditEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		API:       path.Clean(req.URL.Path),
+		Result:    "success",
+	}
+	if tags, ok := logTagsFromContext(req.Context()); ok {
+		entry.ResourceType = tags.resourceType
+		entry.Operation = tags.operation
+		entry.ChangedAttributes = tags.changedAttributes()
+		if tags.d != nil {
+			entry.ObjectID = tags.d.Id()
+		}
+	}
+
+	var result apiResult
+	if err != nil {
+		entry.Result = "failure"
+		entry.Error = err.Error()
+	} else {
+		respBody, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		if readErr != nil {
+			return nil, readErr
+		}
+		json.Unmarshal(respBody, &result)
+		if resp.StatusCode != http.StatusOK || !result.Success {
+			entry.Result = "failure"
+			entry.Error = result.Message
+			if entry.Error == "" {
+				entry.Error = resp.Status
+			}
+		}
+	}
+
+	if entry.ObjectID == "" {
+		entry.ObjectID = auditObjectID(body, result)
+	}
+	if werr := t.journal.Write(entry); werr != nil {
+		requestLogger(req).Errorf("Failed to write audit journal %s: %v", t.journal.path, werr)
+	}
+
+	return resp, err
+}
+
+// apiResult is the common part of Centrify API responses
+type apiResult struct {
+	Success bool        `json:"success"`
+	Result  interface{} `json:"Result"`
+	Message string      `json:"Message"`
+}
+
+// auditObjectID returns ID of the object an API call is made for when resource ID isn't known yet, which
+// is either ID argument of the request or ID of newly created object returned in result
+func auditObjectID(reqBody []byte, result apiResult) string {
+	var args map[string]interface{}
+	if json.Unmarshal(reqBody, &args) == nil {
+		if id, ok := args["ID"].(string); ok && id != "" {
+			return id
+		}
+	}
+	switch v := result.Result.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if id, ok := v["ID"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// changedAttributes returns names of top level attributes that are set or changed by current operation
+func (t logTags) changedAttributes() []string {
+	if t.d == nil || t.schema == nil || t.operation == "delete" {
+		return nil
+	}
+	var names []string
+	for name, s := range t.schema {
+		if s.Computed && !s.Optional {
+			continue
+		}
+		if t.d.HasChange(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
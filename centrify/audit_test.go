@@ -0,0 +1,132 @@
+package centrify
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
+)
+
+func readAuditJournal(t *testing.T, path string) []auditEntry {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid audit journal line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestConfigGetClient_auditJournal(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	dir := testTempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	config := Config{
+		URL:              server.URL,
+		AppID:            server.AppID,
+		Scope:            server.Scope,
+		Username:         server.ClientID,
+		Password:         server.ClientSecret,
+		SkipCertVerify:   true,
+		AuditJournalPath: path,
+	}
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	r := Provider().ResourcesMap["centrify_user"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username":         "audituser@example.com",
+		"password":         "AuditUser@123",
+		"confirm_password": "AuditUser@123",
+	})
+	if err := r.Create(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entries := readAuditJournal(t, path)
+	if len(entries) == 0 {
+		t.Fatal("Expected user creation to be recorded in audit journal")
+	}
+	entry := entries[0]
+	if entry.ResourceType != "centrify_user" || entry.Operation != "create" || entry.Result != "success" {
+		t.Errorf("Unexpected audit entry %+v", entry)
+	}
+	if entry.ObjectID != d.Id() {
+		t.Errorf("Expected object ID %s, got %s", d.Id(), entry.ObjectID)
+	}
+	changed := "," + strings.Join(entry.ChangedAttributes, ",") + ","
+	for _, name := range []string{"username", "password"} {
+		if !strings.Contains(changed, ","+name+",") {
+			t.Errorf("Expected %s in changed attributes %v", name, entry.ChangedAttributes)
+		}
+	}
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.API), "query") || strings.Contains(strings.ToLower(e.API), "get") {
+			t.Errorf("Read only API call %s is recorded in audit journal", e.API)
+		}
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "AuditUser@123") {
+		t.Errorf("Sensitive value is written to audit journal: %s", content)
+	}
+}
+
+func TestAuditTransport_failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"Result":null,"Message":"Role not found"}`))
+	}))
+	defer server.Close()
+	dir := testTempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	journal, err := newAuditJournal(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &auditTransport{base: http.DefaultTransport, journal: journal}}
+	resp, err := client.Post(server.URL+"/Roles/DeleteRole", "application/json", strings.NewReader(`{"ID":"1234"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	resp, err = client.Post(server.URL+"/Roles/GetRole", "application/json", strings.NewReader(`{"ID":"1234"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	entries := readAuditJournal(t, path)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(entries))
+	}
+	if entries[0].API != "/Roles/DeleteRole" || entries[0].ObjectID != "1234" || entries[0].Result != "failure" || entries[0].Error != "Role not found" {
+		t.Errorf("Unexpected audit entry %+v", entries[0])
+	}
+}
@@ -2,23 +2,51 @@ package centrify
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/marcozj/golang-sdk/dmc"
 	"github.com/marcozj/golang-sdk/oauth"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// Authentication methods supported by the provider
+const (
+	authMethodClientCredentials = "oauth_client_credentials"
+	authMethodOauthToken        = "oauth_token"
+	authMethodDMC               = "dmc"
+	authMethodTokenFile         = "token_file"
+	authMethodCredentialProcess = "credential_process"
 )
 
 // Config - Centrify Platform client struct
 type Config struct {
-	URL            string
-	AppID          string
-	Scope          string
-	Username       string
-	Password       string
-	Token          string
-	UseDMC         bool
-	LogLevel       string
-	LogPath        string
-	SkipCertVerify bool
+	URL                    string
+	AuthMethod             string
+	AppID                  string
+	Scope                  string
+	Username               string
+	Password               string
+	Token                  string
+	UseDMC                 bool
+	TokenFile              string
+	CredentialProcess      []string
+	LogLevel               string
+	LogPath                string
+	SkipCertVerify         bool
+	CACertFile             string
+	CACertPEM              string
+	ClientCert             string
+	ClientKey              string
+	MaxRetries             int
+	RetryMinWait           time.Duration
+	RetryMaxWait           time.Duration
+	RequestsPerSecond      float64
+	MaxConcurrent          int
+	AuditJournalPath       string
+	VisibilityTimeout      time.Duration
+	RollbackOnFailedCreate bool
+
+	// redactor masks sensitive values in audit journal
+	redactor *redactor
 }
 
 // Valid - Validate provider configuration
@@ -26,47 +54,172 @@ func (c *Config) Valid() error {
 	if c.URL == "" {
 		return fmt.Errorf(" Tenant URL must be provided for the Centrify provider")
 	}
-	if c.Scope == "" {
-		return fmt.Errorf(" Scope must be provided for the Centrify provider")
-	}
 
-	if !c.UseDMC && c.Token == "" {
-		// If DMC isn't used and token isn't supplied, make sure appid user username is provided
+	switch c.authMethod() {
+	case authMethodClientCredentials:
 		if c.AppID == "" {
-			return fmt.Errorf(" AppID must be provided for the Centrify provider")
+			return fmt.Errorf(" AppID must be provided for %s authentication", authMethodClientCredentials)
+		}
+		if c.Scope == "" {
+			return fmt.Errorf(" Scope must be provided for %s authentication", authMethodClientCredentials)
 		}
 		if c.Username == "" {
-			return fmt.Errorf(" Username or token must be provided for the Centrify provider")
+			return fmt.Errorf(" Client ID must be provided for %s authentication", authMethodClientCredentials)
+		}
+		if c.Password == "" {
+			return fmt.Errorf(" Client secret must be provided for %s authentication", authMethodClientCredentials)
 		}
+	case authMethodOauthToken:
+		if c.Token == "" {
+			return fmt.Errorf(" Token must be provided for %s authentication", authMethodOauthToken)
+		}
+	case authMethodDMC:
+		if c.Scope == "" {
+			return fmt.Errorf(" Scope must be provided for %s authentication", authMethodDMC)
+		}
+	case authMethodTokenFile:
+		if c.TokenFile == "" {
+			return fmt.Errorf(" Path must be provided for %s authentication", authMethodTokenFile)
+		}
+	case authMethodCredentialProcess:
+		if len(c.CredentialProcess) == 0 || c.CredentialProcess[0] == "" {
+			return fmt.Errorf(" Command must be provided for %s authentication", authMethodCredentialProcess)
+		}
+	default:
+		return fmt.Errorf(" Unsupported authentication method %s", c.AuthMethod)
+	}
+
+	if c.CACertFile != "" && c.CACertPEM != "" {
+		return fmt.Errorf(" Only one of ca_cert_file and ca_cert_pem can be provided for the Centrify provider")
+	}
+	if _, err := c.tlsConfig(); err != nil {
+		return err
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf(" max_retries must not be negative")
+	}
+	if c.RetryMinWait < 0 || c.RetryMaxWait < 0 {
+		return fmt.Errorf(" retry_min_wait and retry_max_wait must not be negative")
+	}
+	if c.RetryMaxWait < c.RetryMinWait {
+		return fmt.Errorf(" retry_max_wait must not be less than retry_min_wait")
+	}
+	if c.RequestsPerSecond < 0 {
+		return fmt.Errorf(" requests_per_second must not be negative")
+	}
+	if c.MaxConcurrent < 0 {
+		return fmt.Errorf(" max_concurrent_requests must not be negative")
+	}
+	if c.VisibilityTimeout < 0 {
+		return fmt.Errorf(" visibility_timeout must not be negative")
 	}
 
 	return nil
 }
 
-func (c *Config) getClient() (interface{}, error) {
-	var client interface{}
-	var err error
-	if c.UseDMC {
-		// use DMC to return authenticated Rest client
-		call := dmc.DMC{}
-		call.Service = c.URL
-		call.Scope = c.Scope
-		call.Token = c.Token
-		call.SkipCertVerify = c.SkipCertVerify
-
-		client, err = call.GetClient()
-	} else {
-		// use OAuth authentication
-		call := oauth.OauthClient{
-			Service:        c.URL,
-			AppID:          c.AppID,
-			Scope:          c.Scope,
-			Token:          c.Token,
-			ClientID:       c.Username,
-			ClientSecret:   c.Password,
-			SkipCertVerify: c.SkipCertVerify,
+// getClient authenticates to tenant and returns API client together with provider settings as provider meta
+func (c *Config) getClient() (*providerMeta, error) {
+	httpFactory, err := c.httpClientFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticate upfront so that configuration errors are reported by provider configuration
+	source := newCachedTokenSource(c.tokenFetcher(httpFactory))
+	token, err := source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	call := oauth.OauthClient{Service: c.URL}
+	client, err := call.GetRestClient(&oauth.TokenResponse{AccessToken: token, TokenType: "Bearer"})
+	if err != nil {
+		return nil, err
+	}
+
+	// Use our own transport so that custom CA and client certificate are honored, rate and concurrency
+	// of API calls are limited, token is renewed when it expires, throttled and transient failures
+	// of API calls are retried
+	limiter := newLimitTransport(httpFactory().Transport, c.RequestsPerSecond, c.MaxConcurrent)
+	transport := &authTransport{base: limiter, source: source}
+	client.Client.Transport = newRetryTransport(transport, c.MaxRetries, c.RetryMinWait, c.RetryMaxWait)
+
+	if c.AuditJournalPath != "" {
+		journal, err := newAuditJournal(c.AuditJournalPath, c.redactor)
+		if err != nil {
+			return nil, err
+		}
+		client.Client.Transport = &auditTransport{base: client.Client.Transport, journal: journal}
+	}
+
+	return &providerMeta{
+		client:                 client,
+		visibilityTimeout:      c.VisibilityTimeout,
+		rollbackOnFailedCreate: c.RollbackOnFailedCreate,
+	}, nil
+}
+
+// tokenFetcher returns token fetcher of the configured authentication method
+func (c *Config) tokenFetcher(httpFactory oauth.HttpClientFactory) tokenFetcher {
+	switch c.authMethod() {
+	case authMethodDMC:
+		if c.Token != "" {
+			return staticToken(c.Token)
 		}
-		client, err = call.GetClient()
+		return &dmcFetcher{scope: c.Scope}
+	case authMethodTokenFile:
+		return &tokenFileFetcher{path: c.TokenFile}
+	case authMethodCredentialProcess:
+		return &credentialProcessFetcher{config: c, command: c.CredentialProcess}
+	case authMethodOauthToken:
+		return staticToken(c.Token)
+	default:
+		return &oauthFetcher{config: c, httpFactory: httpFactory}
+	}
+}
+
+// authMethod returns configured authentication method. If auth block isn't used, it is derived from
+// use_dmc, token, username and password arguments for backward compatibility.
+func (c *Config) authMethod() string {
+	if c.AuthMethod != "" {
+		return c.AuthMethod
 	}
-	return client, err
+	if c.UseDMC {
+		return authMethodDMC
+	}
+	if c.Token != "" {
+		return authMethodOauthToken
+	}
+	return authMethodClientCredentials
+}
+
+// getOauthToken obtains OAuth token with client credentials. It does the same as oauth.OauthClient.GetOauthToken
+// but uses the given HTTP client factory so that TLS settings are honored.
+func (c *Config) getOauthToken(httpFactory oauth.HttpClientFactory) (*oauth.TokenResponse, error) {
+	oclient, err := oauth.GetNewConfidentialClient(c.URL, c.Username, c.Password, httpFactory)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get confidential client: %v", err)
+	}
+	oclient.SourceHeader = restapi.SourceHeader
+
+	token, failure, err := oclient.ClientCredentials(c.AppID, c.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get confidential client token: %v", err)
+	}
+	if failure != nil {
+		return nil, fmt.Errorf("Failed to get oauth token, failure: %v", failure)
+	}
+
+	return token, nil
+}
+
+// providerMeta is returned by provider configuration and passed to every resource operation. It holds the API
+// client and provider settings that resources need besides it.
+type providerMeta struct {
+	client                 *restapi.RestClient
+	visibilityTimeout      time.Duration
+	rollbackOnFailedCreate bool
+	// operation is set in copy of provider meta given to a resource operation
+	operation logTags
 }
@@ -0,0 +1,306 @@
+package centrify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcozj/golang-sdk/dmc"
+	logger "github.com/marcozj/golang-sdk/logging"
+	"github.com/marcozj/golang-sdk/oauth"
+)
+
+// tokenExpirySkew is how long before actual expiry a token is treated as expired
+const tokenExpirySkew = 30 * time.Second
+
+// credentialProcessTimeout is the maximum time credential process is allowed to run
+const credentialProcessTimeout = time.Minute
+
+// tokenSource provides bearer token for API calls
+type tokenSource interface {
+	// Token returns current token, obtaining a new one if there isn't any or it has expired
+	Token() (string, error)
+	// Invalidate discards token rejected by the tenant so that next Token call obtains a new one
+	Invalidate(token string)
+}
+
+// tokenFetcher obtains a new token and its expiry. Zero expiry means the expiry is unknown.
+type tokenFetcher interface {
+	fetch() (string, time.Time, error)
+}
+
+// cachedTokenSource caches token obtained by a fetcher until it expires or is rejected. Fetching is serialized
+// so that concurrent requests failing with the same expired token result in a single refresh.
+type cachedTokenSource struct {
+	fetcher tokenFetcher
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newCachedTokenSource(fetcher tokenFetcher) *cachedTokenSource {
+	return &cachedTokenSource{fetcher: fetcher}
+}
+
+// Token returns cached token or fetches a new one
+func (s *cachedTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stale := false
+	if v, ok := s.fetcher.(interface{ changed() bool }); ok {
+		stale = v.changed()
+	}
+	if s.token != "" && !stale && !tokenExpired(s.expiry) {
+		return s.token, nil
+	}
+
+	renew := s.token != ""
+	token, expiry, err := s.fetcher.fetch()
+	if err != nil {
+		return "", err
+	}
+	if expiry.IsZero() {
+		expiry = jwtExpiry(token)
+	}
+	if renew {
+		logger.Infof("Access token is renewed")
+	}
+	s.token = token
+	s.expiry = expiry
+
+	return s.token, nil
+}
+
+// Invalidate discards token if it is still the cached one
+func (s *cachedTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// authTransport - http.RoundTripper that sets Authorization header from a token source on every request.
+// Request rejected with HTTP 401 is replayed once with renewed token.
+type authTransport struct {
+	base   http.RoundTripper
+	source tokenSource
+}
+
+// RoundTrip sets current token and sends the request
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(withToken(req, body, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Token is expired or revoked. Obtain a new one and replay the request.
+	t.source.Invalidate(token)
+	renewed, err := t.source.Token()
+	if err != nil {
+		requestLogger(req).Errorf("Failed to renew access token: %v", err)
+		return resp, nil
+	}
+	if renewed == token {
+		return resp, nil
+	}
+	requestLogger(req).Infof("Replaying %s with renewed access token", req.URL.Path)
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.base.RoundTrip(withToken(req, body, renewed))
+}
+
+func withToken(req *http.Request, body []byte, token string) *http.Request {
+	r := withRequestBody(req, body)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// staticToken is token provided in configuration which can't be renewed
+type staticToken string
+
+func (t staticToken) fetch() (string, time.Time, error) {
+	return string(t), time.Time{}, nil
+}
+
+// oauthFetcher obtains token with OAuth2 client credentials
+type oauthFetcher struct {
+	config      *Config
+	httpFactory oauth.HttpClientFactory
+}
+
+func (f *oauthFetcher) fetch() (string, time.Time, error) {
+	token, err := f.config.getOauthToken(f.httpFactory)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token.AccessToken, expiry, nil
+}
+
+// dmcFetcher obtains token from local Centrify Client through DMC
+type dmcFetcher struct {
+	scope string
+}
+
+func (f *dmcFetcher) fetch() (string, time.Time, error) {
+	token, err := dmc.NewLRPC2().GetToken(f.scope)
+	return token, time.Time{}, err
+}
+
+// tokenFileFetcher reads token from a file. The file is read again when token expires or file is modified.
+type tokenFileFetcher struct {
+	path    string
+	modTime time.Time
+}
+
+func (f *tokenFileFetcher) changed() bool {
+	info, err := os.Stat(f.path)
+	// Let fetch report the error
+	return err != nil || !info.ModTime().Equal(f.modTime)
+}
+
+func (f *tokenFileFetcher) fetch() (string, time.Time, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf(" Failed to read token file: %v", err)
+	}
+	content, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf(" Failed to read token file: %v", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf(" Token file %s is empty", f.path)
+	}
+	f.modTime = info.ModTime()
+
+	return token, time.Time{}, nil
+}
+
+// credentialProcessOutput is JSON document printed by credential process
+type credentialProcessOutput struct {
+	Token        string `json:"token"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+// credentialProcessFetcher runs an external command to obtain token or OAuth client credentials
+type credentialProcessFetcher struct {
+	config  *Config
+	command []string
+}
+
+func (f *credentialProcessFetcher) fetch() (string, time.Time, error) {
+	output, err := f.run()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var expiry time.Time
+	if output.ExpiresAt != "" {
+		expiry, err = time.Parse(time.RFC3339, output.ExpiresAt)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf(" Invalid expires_at in credential process output: %v", err)
+		}
+	}
+	if output.Token != "" {
+		return output.Token, expiry, nil
+	}
+
+	if output.ClientID == "" || output.ClientSecret == "" {
+		return "", time.Time{}, fmt.Errorf(" Credential process output must contain either token or client_id and client_secret")
+	}
+	if f.config.AppID == "" || f.config.Scope == "" {
+		return "", time.Time{}, fmt.Errorf(" appid and scope must be provided when credential process returns client credentials")
+	}
+	c := *f.config
+	c.Username = output.ClientID
+	c.Password = output.ClientSecret
+	httpFactory, err := c.httpClientFactory()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token, tokenExpiry, err := (&oauthFetcher{config: &c, httpFactory: httpFactory}).fetch()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if expiry.IsZero() {
+		expiry = tokenExpiry
+	}
+
+	return token, expiry, nil
+}
+
+func (f *credentialProcessFetcher) run() (*credentialProcessOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+	defer cancel()
+
+	logger.Infof("Running credential process %s", f.command[0])
+	cmd := exec.CommandContext(ctx, f.command[0], f.command[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf(" Credential process %s failed: %v %s", f.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := &credentialProcessOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), output); err != nil {
+		return nil, fmt.Errorf(" Credential process %s didn't print valid JSON: %v", f.command[0], err)
+	}
+
+	return output, nil
+}
+
+// tokenExpired reports whether token with the given expiry needs to be renewed. Zero expiry never expires.
+func tokenExpired(expiry time.Time) bool {
+	return !expiry.IsZero() && time.Now().Add(tokenExpirySkew).After(expiry)
+}
+
+// jwtExpiry returns expiry time from exp claim of JWT token. Zero time is returned if token isn't JWT.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
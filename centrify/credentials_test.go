@@ -0,0 +1,180 @@
+package centrify
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
+)
+
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".sig"
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "centrify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func testUserCreate(t *testing.T, meta *providerMeta) {
+	user := vault.NewUser(meta.client)
+	user.Name = "testuser@example.com"
+	user.Password = "TestUser@123"
+	user.ConfirmPassword = user.Password
+	if _, err := user.Create(); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	if got := jwtExpiry(testJWT(exp)); !got.Equal(exp) {
+		t.Errorf("Expected %v, got %v", exp, got)
+	}
+	if got := jwtExpiry("opaque-token"); !got.IsZero() {
+		t.Errorf("Expected zero time for opaque token, got %v", got)
+	}
+}
+
+func TestTokenFileSource_reread(t *testing.T) {
+	dir := testTempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	expired := testJWT(time.Now().Add(-time.Minute))
+	if err := ioutil.WriteFile(path, []byte(expired+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	source := newCachedTokenSource(&tokenFileFetcher{path: path})
+	if token, err := source.Token(); err != nil || token != expired {
+		t.Fatalf("Expected token from file, got %q, %v", token, err)
+	}
+
+	// Expired token is read again from file
+	renewed := testJWT(time.Now().Add(time.Hour))
+	if err := ioutil.WriteFile(path, []byte(renewed), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := source.Token(); err != nil || token != renewed {
+		t.Fatalf("Expected renewed token, got %q, %v", token, err)
+	}
+}
+
+func TestConfigGetClient_tokenFile(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	dir := testTempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(path, []byte(server.Token), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{URL: server.URL, AuthMethod: authMethodTokenFile, TokenFile: path, SkipCertVerify: true}
+	if err := config.Valid(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testUserCreate(t, meta)
+}
+
+func TestConfigGetClient_credentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test requires POSIX shell")
+	}
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	config := Config{
+		URL:            server.URL,
+		AuthMethod:     authMethodCredentialProcess,
+		AppID:          server.AppID,
+		Scope:          server.Scope,
+		SkipCertVerify: true,
+		CredentialProcess: []string{"sh", "-c", fmt.Sprintf(`echo '{"client_id":"%s","client_secret":"%s"}'`,
+			server.ClientID, server.ClientSecret)},
+	}
+	if err := config.Valid(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	testUserCreate(t, meta)
+
+	config.CredentialProcess = []string{"sh", "-c", "echo not json"}
+	if _, err := config.getClient(); err == nil {
+		t.Fatal("Expected error for invalid credential process output")
+	}
+}
+
+func TestConfigGetClient_tokenRefresh(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	config := testMockTenantConfig(server)
+	config.SkipCertVerify = true
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server.RevokeTokens()
+
+	// Concurrent requests rejected with the expired token must trigger a single refresh
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user := vault.NewUser(meta.client)
+			user.Name = fmt.Sprintf("testuser%d@example.com", i)
+			user.Password = "TestUser@123"
+			user.ConfirmPassword = user.Password
+			if _, err := user.Create(); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Failed to create user after token expiry: %v", err)
+	}
+	if n := server.TokensIssued(); n != 2 {
+		t.Errorf("Expected 2 tokens to be issued, got %d", n)
+	}
+}
+
+func TestConfigGetClient_staticTokenRejected(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	config := Config{URL: server.URL, Token: "invalid", SkipCertVerify: true}
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	user := vault.NewUser(meta.client)
+	user.ID = "anything"
+	if err := user.Read(); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("Expected HTTP 401 error, got %v", err)
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceAuthenticationProfile_deprecated() *schema.Resource {
@@ -71,8 +69,8 @@ func getDSAuthenticationProfileSchema() map[string]*schema.Schema {
 }
 
 func dataSourceAuthenticationProfileRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding authentication profile")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding authentication profile")
+	client := m.(*providerMeta).client
 	object := vault.NewAuthenticationProfile(client)
 	object.Name = d.Get("name").(string)
 
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceCloudProvider_deprecated() *schema.Resource {
@@ -78,8 +76,8 @@ func getDSCloudProviderSchema() map[string]*schema.Schema {
 }
 
 func dataSourceCloudProviderRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding CloudProvider")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding CloudProvider")
+	client := m.(*providerMeta).client
 	object := vault.NewCloudProvider(client)
 	object.CloudAccountID = d.Get("cloud_account_id").(string)
 	object.Name = d.Get("name").(string)
@@ -5,9 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceConnector_deprecated() *schema.Resource {
@@ -103,8 +101,8 @@ func getDSConnectorSchema() map[string]*schema.Schema {
 }
 
 func dataSourceConnectorRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding connector")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding connector")
+	client := m.(*providerMeta).client
 	object := vault.NewConnector(client)
 	object.Name = d.Get("name").(string)
 	object.MachineName = d.Get("machine_name").(string)
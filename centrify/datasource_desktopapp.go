@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceDesktopApp_deprecated() *schema.Resource {
@@ -117,8 +115,8 @@ func getDSDesktopAppSchema() map[string]*schema.Schema {
 }
 
 func dataSourceDesktopAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding DesktopApp")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding DesktopApp")
+	client := m.(*providerMeta).client
 	object := vault.NewDesktopApp(client)
 	object.Name = d.Get("name").(string)
 
@@ -5,9 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceDirectoryObject_deprecated() *schema.Resource {
@@ -79,8 +77,8 @@ func getDSDirectoryObjectSchema() map[string]*schema.Schema {
 }
 
 func dataSourceDirectoryObjectRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Directory Object")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Directory Object")
+	client := m.(*providerMeta).client
 	object := vault.NewDirectoryObjects(client)
 	object.QueryName = d.Get("name").(string)
 	object.ObjectType = d.Get("object_type").(string)
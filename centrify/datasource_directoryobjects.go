@@ -0,0 +1,138 @@
+package centrify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceDirectoryObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDirectoryObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Type of the directory objects. Directory service query is per object type so it must be set",
+				ValidateFunc: validation.StringInSlice([]string{
+					"User",
+					"Group",
+				}, false),
+			},
+			"directory_services": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "List of UUID of directory services. Defaults to all directory services",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return objects whose system name starts with this prefix, ignoring case",
+			},
+			"distinguished_name_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return objects whose distinguished name ends with this suffix, ignoring case. Use it to scope to an OU",
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Directory objects that match all filters, ordered by system name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"system_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"distinguished_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"forest": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDirectoryObjectsRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Finding directory objects")
+	client := m.(*providerMeta).client
+	object := vault.NewDirectoryObjects(client)
+	// Directory service query matches anywhere in system name, so prefix is checked again below
+	object.QueryName = d.Get("name_prefix").(string)
+	object.ObjectType = d.Get("object_type").(string)
+	object.DirectoryServices = flattenSchemaSetToStringSlice(d.Get("directory_services"))
+	if len(object.DirectoryServices) == 0 {
+		services := vault.NewDirectoryServices(client)
+		if err := services.Read(); err != nil {
+			return fmt.Errorf(" Error retrieving directory services: %v", err)
+		}
+		for _, v := range services.DirServices {
+			object.DirectoryServices = append(object.DirectoryServices, v.ID)
+		}
+	}
+
+	if err := object.Read(); err != nil {
+		return fmt.Errorf(" Error finding directory objects: %v", err)
+	}
+
+	dnSuffix := strings.ToLower(d.Get("distinguished_name_suffix").(string))
+
+	objects := []interface{}{}
+	for _, v := range object.DirectoryObjects {
+		if !hasPrefixFold(v.SystemName, object.QueryName) {
+			continue
+		}
+		if !strings.HasSuffix(strings.ToLower(v.DistinguishedName), dnSuffix) {
+			continue
+		}
+		objects = append(objects, map[string]interface{}{
+			"id":                 v.ID,
+			"name":               v.Name,
+			"type":               object.ObjectType,
+			"system_name":        v.SystemName,
+			"display_name":       v.DisplayName,
+			"distinguished_name": v.DistinguishedName,
+			"forest":             v.Forest,
+		})
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].(map[string]interface{})["system_name"].(string) < objects[j].(map[string]interface{})["system_name"].(string)
+	})
+	logFor(m).Debugf("Found %d directory objects", len(objects))
+
+	d.SetId(hashcode.Strings(append([]string{object.ObjectType, object.QueryName, dnSuffix}, object.DirectoryServices...)))
+	d.Set("objects", objects)
+
+	return nil
+}
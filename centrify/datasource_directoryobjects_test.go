@@ -0,0 +1,87 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceDirectoryObjectsRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	ad := server.Insert("DirectoryServices", map[string]interface{}{"Name": "example.com", "Service": "AdProxy"})
+	other := server.Insert("DirectoryServices", map[string]interface{}{"Name": "example.org", "Service": "AdProxy"})
+
+	insert := func(objectType, service, systemName, dn string) string {
+		return server.Insert("DirectoryObjects", map[string]interface{}{
+			"ObjectType":           objectType,
+			"DirectoryServiceUuid": service,
+			"Name":                 systemName,
+			"SystemName":           systemName,
+			"DistinguishedName":    dn,
+			"Forest":               "example.com",
+		})
+	}
+	insert("User", ad, "lab.bob@example.com", "CN=Bob,OU=Admins,OU=Lab,DC=example,DC=com")
+	alice := insert("User", ad, "lab.alice@example.com", "CN=Alice,OU=Admins,OU=Lab,DC=example,DC=com")
+	insert("User", ad, "carol@example.com", "CN=Carol,OU=Staff,DC=example,DC=com")
+	insert("User", ad, "carol.lab@example.com", "CN=Carol Lab,OU=Staff,DC=example,DC=com")
+	insert("User", other, "lab.dave@example.org", "CN=Dave,OU=Admins,OU=Lab,DC=example,DC=org")
+	insert("Group", ad, "lab admins@example.com", "CN=LAB Admins,OU=Lab,DC=example,DC=com")
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []string
+	}{
+		{
+			map[string]interface{}{"object_type": "User", "directory_services": []interface{}{ad}},
+			[]string{"carol.lab@example.com", "carol@example.com", "lab.alice@example.com", "lab.bob@example.com"},
+		},
+		{
+			map[string]interface{}{"object_type": "User", "directory_services": []interface{}{ad, other}, "name_prefix": "LAB."},
+			[]string{"lab.alice@example.com", "lab.bob@example.com", "lab.dave@example.org"},
+		},
+		{
+			map[string]interface{}{"object_type": "User", "directory_services": []interface{}{ad, other}, "distinguished_name_suffix": "ou=admins,ou=lab,dc=example,dc=com"},
+			[]string{"lab.alice@example.com", "lab.bob@example.com"},
+		},
+		{
+			map[string]interface{}{"object_type": "Group", "directory_services": []interface{}{ad}, "distinguished_name_suffix": "OU=Lab,DC=example,DC=com"},
+			[]string{"lab admins@example.com"},
+		},
+		{
+			map[string]interface{}{"object_type": "Group", "directory_services": []interface{}{other}},
+			nil,
+		},
+		{
+			map[string]interface{}{"object_type": "User", "name_prefix": "lab."},
+			[]string{"lab.alice@example.com", "lab.bob@example.com", "lab.dave@example.org"},
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceDirectoryObjects().Schema, c.config)
+		if err := dataSourceDirectoryObjectsRead(d, meta); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.config, err)
+		}
+		var names []string
+		for _, o := range d.Get("objects").([]interface{}) {
+			names = append(names, o.(map[string]interface{})["system_name"].(string))
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%v: expected %v, got %v", c.config, c.names, names)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceDirectoryObjects().Schema, map[string]interface{}{"object_type": "User", "directory_services": []interface{}{ad}, "name_prefix": "lab.alice"})
+	if err := dataSourceDirectoryObjectsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := d.Get("objects.0.id"); got != alice {
+		t.Errorf("expected id %s, got %v", alice, got)
+	}
+	if got := d.Get("objects.0.type"); got != "User" {
+		t.Errorf("expected type User, got %v", got)
+	}
+}
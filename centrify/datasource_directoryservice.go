@@ -6,9 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/directoryservice"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceDirectoryService_deprecated() *schema.Resource {
@@ -57,8 +55,8 @@ func getDSDirectoryServiceSchema() map[string]*schema.Schema {
 }
 
 func dataSourceDirectoryServiceRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding DirectoryService")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding DirectoryService")
+	client := m.(*providerMeta).client
 	object := vault.NewDirectoryServices(client)
 
 	err := object.Read()
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceFederatedGroup() *schema.Resource {
@@ -28,8 +26,8 @@ func getDSFederatedGroupSchema() map[string]*schema.Schema {
 }
 
 func dataSourceFederatedGroupRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding federated group")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding federated group")
+	client := m.(*providerMeta).client
 	object := vault.NewFederatedGroup(client)
 	object.Name = d.Get("name").(string)
 
@@ -43,7 +41,7 @@ func dataSourceFederatedGroupRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map: %+v", schemamap)
+	logFor(m).Debugf("Generated Map: %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
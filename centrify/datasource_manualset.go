@@ -6,9 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/settype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceManualSet_deprecated() *schema.Resource {
@@ -71,8 +69,8 @@ func getDSManualSetSchema() map[string]*schema.Schema {
 }
 
 func dataSourceManualSetRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Manual Set")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Manual Set")
+	client := m.(*providerMeta).client
 	object := vault.NewManualSet(client)
 	object.Name = d.Get("name").(string)
 	object.ObjectType = d.Get("type").(string)
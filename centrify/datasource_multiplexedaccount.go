@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceMultiplexedAccount_deprecated() *schema.Resource {
@@ -72,8 +70,8 @@ func getDSMultiplexedAccountSchema() map[string]*schema.Schema {
 }
 
 func dataSourceMultiplexedAccountRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding multiplexed account")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding multiplexed account")
+	client := m.(*providerMeta).client
 	object := vault.NewMultiplexedAccount(client)
 	object.Name = d.Get("name").(string)
 
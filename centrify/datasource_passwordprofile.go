@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourcePasswordProfile_deprecated() *schema.Resource {
@@ -113,8 +111,8 @@ func getDSPasswordProfileSchema() map[string]*schema.Schema {
 }
 
 func dataSourcePasswordProfileRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding password profile")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding password profile")
+	client := m.(*providerMeta).client
 	object := vault.NewPasswordProfile(client)
 	object.Name = d.Get("name").(string)
 	object.ProfileType = d.Get("profile_type").(string)
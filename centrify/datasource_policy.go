@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourcePolicy_deprecated() *schema.Resource {
@@ -83,8 +81,8 @@ func getDSPolicySchema() map[string]*schema.Schema {
 }
 
 func dataSourcePolicyRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding policy")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding policy")
+	client := m.(*providerMeta).client
 	object := vault.NewPolicy(client)
 	object.Name = d.Get("name").(string)
 
@@ -98,7 +96,7 @@ func dataSourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map: %+v", schemamap)
+	logFor(m).Debugf("Generated Map: %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "plink":
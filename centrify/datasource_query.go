@@ -0,0 +1,104 @@
+package centrify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceQuery() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Redrock SQL query, which may refer to parameters as @name",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"parameters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Values of parameters referred to by query",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_rows": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10000,
+				Description:  "Maximum number of rows. Reading fails if query returns more rows",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rows returned by query. Each row maps column names to values",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceQueryRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Running Redrock query")
+	client := m.(*providerMeta).client
+
+	query := d.Get("query").(string)
+	parameters := make(map[string]string)
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		parameters[k] = v.(string)
+	}
+
+	results, err := redrockQueryPages(client, query, parameters, d.Get("max_rows").(int))
+	if err != nil {
+		return fmt.Errorf(" Error running query %s: %v", query, err)
+	}
+
+	rows := []interface{}{}
+	for _, result := range results {
+		row := make(map[string]interface{})
+		for k, v := range result {
+			row[k] = redrockValueString(v)
+		}
+		rows = append(rows, row)
+	}
+	logFor(m).Debugf("Query returns %d rows", len(rows))
+
+	d.SetId(hashcode.Strings([]string{query, fmt.Sprintf("%v", parameters)}))
+	d.Set("rows", rows)
+
+	return nil
+}
+
+// redrockValueString converts value of a column returned by Redrock query to string. Null becomes empty string, and
+// arrays and objects are encoded as JSON.
+func redrockValueString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+}
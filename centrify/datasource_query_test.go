@@ -0,0 +1,69 @@
+package centrify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceQueryRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	for i := 0; i < 2500; i++ {
+		server.Insert("Proxy", map[string]interface{}{"Name": fmt.Sprintf("connector%04d", i), "Online": i%2 == 0, "Version": 21.6})
+	}
+	server.Insert("Proxy", map[string]interface{}{"Name": "O'Brien", "Online": true, "Version": nil, "Services": []interface{}{"RDP", "SSH"}})
+
+	d := schema.TestResourceDataRaw(t, dataSourceQuery().Schema, map[string]interface{}{"query": "SELECT * FROM Proxy"})
+	if err := dataSourceQueryRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := len(d.Get("rows").([]interface{})); got != 2501 {
+		t.Errorf("expected 2501 rows fetched across pages, got %d", got)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceQuery().Schema, map[string]interface{}{
+		"query":      "SELECT * FROM Proxy WHERE Name=@name",
+		"parameters": map[string]interface{}{"name": "O'Brien"},
+	})
+	if err := dataSourceQueryRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rows := d.Get("rows").([]interface{})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	row := rows[0].(map[string]interface{})
+	for column, want := range map[string]string{"Name": "O'Brien", "Online": "true", "Version": "", "Services": `["RDP","SSH"]`} {
+		if row[column] != want {
+			t.Errorf("expected %s to be %q, got %q", column, want, row[column])
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceQuery().Schema, map[string]interface{}{"query": "SELECT * FROM Proxy", "max_rows": 1500})
+	if err := dataSourceQueryRead(d, meta); err == nil || !strings.Contains(err.Error(), "more than 1500 rows") {
+		t.Errorf("expected max_rows error, got %v", err)
+	}
+}
+
+func TestRedrockValueString(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{false, "false"},
+		{float64(42), "42"},
+		{1.5, "1.5"},
+		{map[string]interface{}{"a": "b"}, `{"a":"b"}`},
+	}
+	for _, c := range cases {
+		if got := redrockValueString(c.value); got != c.want {
+			t.Errorf("%v: expected %q, got %q", c.value, c.want, got)
+		}
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceRole_deprecated() *schema.Resource {
@@ -73,8 +71,8 @@ func getDSRoleSchema() map[string]*schema.Schema {
 }
 
 func dataSourceRoleRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding role")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding role")
+	client := m.(*providerMeta).client
 	object := vault.NewRole(client)
 	object.Name = d.Get("name").(string)
 
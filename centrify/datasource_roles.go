@@ -0,0 +1,86 @@
+package centrify
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRolesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return roles whose name starts with this prefix, ignoring case",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Roles that match all filters, ordered by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRolesRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Finding roles")
+	client := m.(*providerMeta).client
+
+	prefix := d.Get("name_prefix").(string)
+	var conditions redrockConditions
+	conditions.hasPrefix("Name", prefix)
+	query := "SELECT ID, Name, Description FROM Role" + conditions.where()
+
+	rows, err := redrockQueryPages(client, query, nil, 0)
+	if err != nil {
+		return fmt.Errorf(" Error finding roles: %v", err)
+	}
+
+	roles := []interface{}{}
+	for _, row := range rows {
+		name := stringValue(row["Name"])
+		if !hasPrefixFold(name, prefix) {
+			continue
+		}
+		roles = append(roles, map[string]interface{}{
+			"id":          stringValue(row["ID"]),
+			"name":        name,
+			"type":        "Role",
+			"description": stringValue(row["Description"]),
+		})
+	}
+	sort.SliceStable(roles, func(i, j int) bool {
+		return roles[i].(map[string]interface{})["name"].(string) < roles[j].(map[string]interface{})["name"].(string)
+	})
+	logFor(m).Debugf("Found %d roles", len(roles))
+
+	d.SetId(hashcode.Strings([]string{query, prefix}))
+	d.Set("roles", roles)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceRolesRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	// Roles must be read across pages
+	server.SetQueryLimit(2)
+	server.Insert("Role", map[string]interface{}{"Name": "LAB Unix Admins"})
+	windows := server.Insert("Role", map[string]interface{}{"Name": "LAB Windows Admins", "Description": "Windows administrators"})
+	server.Insert("Role", map[string]interface{}{"Name": "Help Desk"})
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []string
+	}{
+		{map[string]interface{}{}, []string{"Help Desk", "LAB Unix Admins", "LAB Windows Admins"}},
+		{map[string]interface{}{"name_prefix": "lab "}, []string{"LAB Unix Admins", "LAB Windows Admins"}},
+		{map[string]interface{}{"name_prefix": "Auditors"}, nil},
+		// _ is a wildcard of LIKE in query
+		{map[string]interface{}{"name_prefix": "LAB_"}, nil},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceRoles().Schema, c.config)
+		if err := dataSourceRolesRead(d, meta); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.config, err)
+		}
+		var names []string
+		for _, r := range d.Get("roles").([]interface{}) {
+			names = append(names, r.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%v: expected %v, got %v", c.config, c.names, names)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceRoles().Schema, map[string]interface{}{"name_prefix": "LAB Windows"})
+	if err := dataSourceRolesRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"id": windows, "name": "LAB Windows Admins", "type": "Role", "description": "Windows administrators"}
+	if got := d.Get("roles.0"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -5,9 +5,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceService_deprecated() *schema.Resource {
@@ -101,8 +99,8 @@ func getDSServiceSchema() map[string]*schema.Schema {
 }
 
 func dataSourceServiceRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Service")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Service")
+	client := m.(*providerMeta).client
 	object := vault.NewService(client)
 	object.Name = d.Get("service_name").(string)
 
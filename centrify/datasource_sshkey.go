@@ -6,9 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/keypairtype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceSSHKey_deprecated() *schema.Resource {
@@ -87,8 +85,8 @@ func getDSSSHKeySchema() map[string]*schema.Schema {
 }
 
 func dataSourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding SSH Key")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding SSH Key")
+	client := m.(*providerMeta).client
 	object := vault.NewSSHKey(client)
 	object.Name = d.Get("name").(string)
 	if v, ok := d.GetOk("key_pair_type"); ok {
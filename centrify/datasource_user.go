@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceUser_deprecated() *schema.Resource {
@@ -94,8 +92,8 @@ func getDSUserSchema() map[string]*schema.Schema {
 }
 
 func dataSourceUserRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding user")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding user")
+	client := m.(*providerMeta).client
 	object := vault.NewUser(client)
 	object.Name = d.Get("username").(string)
 
@@ -0,0 +1,117 @@
+package centrify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUsersRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users whose username starts with this prefix, ignoring case",
+			},
+			"email_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users whose email address is in this domain, ignoring case",
+			},
+			"manager_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users who report to the manager with this username",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Users that match all filters, ordered by username",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Finding users")
+	client := m.(*providerMeta).client
+
+	prefix := d.Get("name_prefix").(string)
+	var conditions redrockConditions
+	conditions.hasPrefix("Username", prefix)
+	if v, ok := d.GetOk("manager_username"); ok {
+		// ReportsTo column holds ID of the manager
+		manager := vault.NewUser(client)
+		manager.Name = v.(string)
+		id, err := manager.GetIDByName()
+		if err != nil {
+			return fmt.Errorf(" Error finding manager %s: %v", manager.Name, err)
+		}
+		conditions.equals("ReportsTo", id)
+	}
+	query := "SELECT ID, Username, DisplayName, Email FROM User" + conditions.where()
+
+	rows, err := redrockQueryPages(client, query, nil, 0)
+	if err != nil {
+		return fmt.Errorf(" Error finding users: %v", err)
+	}
+	domain := strings.TrimPrefix(d.Get("email_domain").(string), "@")
+
+	users := []interface{}{}
+	for _, row := range rows {
+		name, email := stringValue(row["Username"]), stringValue(row["Email"])
+		if !hasPrefixFold(name, prefix) {
+			continue
+		}
+		if domain != "" && !strings.HasSuffix(strings.ToLower(email), "@"+strings.ToLower(domain)) {
+			continue
+		}
+		users = append(users, map[string]interface{}{
+			"id":           stringValue(row["ID"]),
+			"name":         name,
+			"type":         "User",
+			"display_name": stringValue(row["DisplayName"]),
+			"email":        email,
+		})
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].(map[string]interface{})["name"].(string) < users[j].(map[string]interface{})["name"].(string)
+	})
+	logFor(m).Debugf("Found %d users", len(users))
+
+	d.SetId(hashcode.Strings([]string{query, prefix, domain}))
+	d.Set("users", users)
+
+	return nil
+}
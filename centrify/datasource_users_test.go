@@ -0,0 +1,59 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceUsersRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	// ReportsTo holds ID of the manager
+	carol := server.Insert("User", map[string]interface{}{"Username": "carol@example.com", "Email": "carol@example.com"})
+	server.Insert("User", map[string]interface{}{"Username": "svc_backup@example.com", "Email": "backup@ops.example.com", "ReportsTo": "carol@example.com"})
+	alice := server.Insert("User", map[string]interface{}{"Username": "alice@example.com", "DisplayName": "Alice", "Email": "alice@Example.com", "ReportsTo": carol})
+	server.Insert("User", map[string]interface{}{"Username": "bob@example.com", "Email": "bob@example.org", "ReportsTo": carol})
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []string
+	}{
+		{map[string]interface{}{}, []string{"alice@example.com", "bob@example.com", "carol@example.com", "svc_backup@example.com"}},
+		{map[string]interface{}{"name_prefix": "SVC_"}, []string{"svc_backup@example.com"}},
+		{map[string]interface{}{"email_domain": "example.com"}, []string{"alice@example.com", "carol@example.com"}},
+		{map[string]interface{}{"email_domain": "@example.org"}, []string{"bob@example.com"}},
+		{map[string]interface{}{"manager_username": "carol@example.com"}, []string{"alice@example.com", "bob@example.com"}},
+		{map[string]interface{}{"manager_username": "carol@example.com", "email_domain": "example.com"}, []string{"alice@example.com"}},
+		{map[string]interface{}{"name_prefix": "dave"}, nil},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, c.config)
+		if err := dataSourceUsersRead(d, meta); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.config, err)
+		}
+		var names []string
+		for _, u := range d.Get("users").([]interface{}) {
+			names = append(names, u.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%v: expected %v, got %v", c.config, c.names, names)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{"manager_username": "dave@example.com"})
+	if err := dataSourceUsersRead(d, meta); err == nil {
+		t.Error("Expected error for unknown manager")
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{"name_prefix": "alice"})
+	if err := dataSourceUsersRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"id": alice, "name": "alice@example.com", "type": "User", "display_name": "Alice", "email": "alice@Example.com"}
+	if got := d.Get("users.0"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/keypairtype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -176,8 +175,8 @@ func getDSAccountSchema() map[string]*schema.Schema {
 }
 
 func dataSourceAccountRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding vault account")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding vault account")
+	client := m.(*providerMeta).client
 	object := vault.NewAccount(client)
 	object.User = d.Get("name").(string)
 	if v, ok := d.GetOk("host_id"); ok {
@@ -226,33 +225,62 @@ func dataSourceAccountRead(d *schema.ResourceData, m interface{}) error {
 
 	// Checkout credential
 	if d.Get("checkout").(bool) {
-		switch object.CredentialType {
-		case "Password":
-			pw, err := object.CheckoutPassword(d.Get("checkin").(bool))
-			if err != nil {
-				return err
-			}
-			d.Set("password", pw)
-		case "SshKey":
-			//object.CredentialID = result["CredentialId"].(string)
-			sshkey := vault.NewSSHKey(client)
-			sshkey.ID = object.CredentialID
-			sshkey.KeyPairType = d.Get("key_pair_type").(string)
-			sshkey.Passphrase = d.Get("passphrase").(string)
-			sshkey.KeyFormat = "PEM"
-			thekey, err := sshkey.RetriveSSHKey()
-			if err != nil {
-				return err
-			}
-			d.Set("private_key", thekey)
-		case "AwsAccessKey":
-			secretkey, err := object.RetrieveAccessKey(d.Get("access_key_id").(string))
-			if err != nil {
+		coid, _, err := checkoutAccountCredential(client, object, d)
+		if err != nil {
+			return err
+		}
+		if object.CredentialType == "Password" && d.Get("checkin").(bool) {
+			if _, err := object.CheckinPassword(coid); err != nil {
 				return err
 			}
-			d.Set("secret_access_key", secretkey)
 		}
 	}
 
 	return nil
 }
+
+// checkoutAccountCredential checks out password, or retrieves SSH key or AWS secret access key, of account and sets it
+// in password, private_key or secret_access_key attribute. It returns ID of password checkout, which is empty for other
+// credential types as they can't be checked in, and checkout lifetime (minutes) if tenant returns it.
+func checkoutAccountCredential(client *restapi.RestClient, object *vault.Account, d *schema.ResourceData) (string, int, error) {
+	switch object.CredentialType {
+	case "Password":
+		// Account.CheckoutPassword doesn't return checkout ID, which is needed to check in later
+		resp, err := client.CallGenericMapAPI("/ServerManage/CheckoutPassword", map[string]interface{}{
+			"ID":          object.ID,
+			"Description": "Checkout by Terraform",
+		})
+		if err != nil {
+			return "", 0, err
+		}
+		if !resp.Success {
+			return "", 0, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+		}
+		pw, ok := resp.Result["Password"].(string)
+		if !ok {
+			return "", 0, fmt.Errorf("Password checkout call doesn't contain password")
+		}
+		d.Set("password", pw)
+		coid, _ := resp.Result["COID"].(string)
+		lifetime, _ := resp.Result["Lifetime"].(float64)
+		return coid, int(lifetime), nil
+	case "SshKey":
+		sshkey := vault.NewSSHKey(client)
+		sshkey.ID = object.CredentialID
+		sshkey.KeyPairType = d.Get("key_pair_type").(string)
+		sshkey.Passphrase = d.Get("passphrase").(string)
+		sshkey.KeyFormat = "PEM"
+		thekey, err := sshkey.RetriveSSHKey()
+		if err != nil {
+			return "", 0, err
+		}
+		d.Set("private_key", thekey)
+	case "AwsAccessKey":
+		secretkey, err := object.RetrieveAccessKey(d.Get("access_key_id").(string))
+		if err != nil {
+			return "", 0, err
+		}
+		d.Set("secret_access_key", secretkey)
+	}
+	return "", 0, nil
+}
@@ -0,0 +1,185 @@
+package centrify
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceAccounts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAccountsRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"domain_id", "database_id", "cloudprovider_id"},
+				Description:   "Only return accounts of this system",
+			},
+			"domain_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"host_id", "database_id", "cloudprovider_id"},
+				Description:   "Only return accounts of this domain",
+			},
+			"database_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"domain_id", "host_id", "cloudprovider_id"},
+				Description:   "Only return accounts of this database",
+			},
+			"cloudprovider_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"domain_id", "host_id", "database_id"},
+				Description:   "Only return accounts of this cloud provider",
+			},
+			"credential_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return accounts with this credential type",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Password",
+					"SshKey",
+					"AwsAccessKey",
+				}, false),
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return accounts that are managed, or unmanaged if false",
+			},
+			"is_admin_account": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return administrative accounts, or other accounts if false",
+			},
+			"set_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return accounts that are members of this set",
+			},
+			"accounts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Accounts that match all filters, ordered by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"domain_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"database_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cloudprovider_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"credential_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"managed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_admin_account": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountsRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Finding accounts")
+	client := m.(*providerMeta).client
+
+	var conditions redrockConditions
+	conditions.equals("Host", d.Get("host_id").(string))
+	conditions.equals("DomainID", d.Get("domain_id").(string))
+	conditions.equals("DatabaseID", d.Get("database_id").(string))
+	conditions.equals("CloudProviderId", d.Get("cloudprovider_id").(string))
+	conditions.equals("CredentialType", d.Get("credential_type").(string))
+	query := "SELECT ID, User, Host, DomainID, DatabaseID, CloudProviderId, CredentialType, IsManaged, IsAdminAccount FROM VaultAccount" + conditions.where()
+
+	rows, err := redrockQueryPages(client, query, nil, 0)
+	if err != nil {
+		return fmt.Errorf(" Error finding accounts: %v", err)
+	}
+
+	// Flags are compared here rather than in query as GetOk can't tell false from unset
+	flags := make(map[string]bool)
+	if v, ok := d.GetOkExists("managed"); ok {
+		flags["IsManaged"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("is_admin_account"); ok {
+		flags["IsAdminAccount"] = v.(bool)
+	}
+	var members map[string]bool
+	if v, ok := d.GetOk("set_id"); ok {
+		if members, err = setMembers(client, v.(string)); err != nil {
+			return fmt.Errorf(" Error reading members of set %s: %v", v.(string), err)
+		}
+	}
+
+	accounts := []interface{}{}
+	for _, row := range rows {
+		id := stringValue(row["ID"])
+		if members != nil && !members[id] {
+			continue
+		}
+		matched := true
+		for column, want := range flags {
+			if got, _ := row[column].(bool); got != want {
+				matched = false
+			}
+		}
+		if !matched {
+			continue
+		}
+		managed, _ := row["IsManaged"].(bool)
+		isAdmin, _ := row["IsAdminAccount"].(bool)
+		accounts = append(accounts, map[string]interface{}{
+			"id":               id,
+			"name":             stringValue(row["User"]),
+			"host_id":          stringValue(row["Host"]),
+			"domain_id":        stringValue(row["DomainID"]),
+			"database_id":      stringValue(row["DatabaseID"]),
+			"cloudprovider_id": stringValue(row["CloudProviderId"]),
+			"credential_type":  stringValue(row["CredentialType"]),
+			"managed":          managed,
+			"is_admin_account": isAdmin,
+		})
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].(map[string]interface{})["name"].(string) < accounts[j].(map[string]interface{})["name"].(string)
+	})
+	logFor(m).Debugf("Found %d accounts", len(accounts))
+
+	d.SetId(hashcode.Strings([]string{query, fmt.Sprintf("%v", flags), d.Get("set_id").(string)}))
+	d.Set("accounts", accounts)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	vault "github.com/marcozj/golang-sdk/platform"
+)
+
+func TestDataSourceAccountsRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	// Accounts must be read across pages
+	server.SetQueryLimit(2)
+	server.Insert("VaultAccount", map[string]interface{}{"User": "root", "Host": "s1", "CredentialType": "Password", "IsManaged": true, "IsAdminAccount": true})
+	oracle := server.Insert("VaultAccount", map[string]interface{}{"User": "oracle", "Host": "s1", "CredentialType": "SshKey", "IsManaged": false})
+	server.Insert("VaultAccount", map[string]interface{}{"User": "svc_backup", "DomainID": "d1", "CredentialType": "Password", "IsManaged": true})
+	server.Insert("VaultAccount", map[string]interface{}{"User": "sa", "DatabaseID": "db1", "CredentialType": "Password", "IsManaged": false})
+
+	set := vault.NewManualSet(meta.client)
+	set.ID = server.Insert("Sets", map[string]interface{}{"Name": "Oracle Accounts", "ObjectType": "VaultAccount", "CollectionType": "ManualBucket"})
+	set.ObjectType = "VaultAccount"
+	if _, err := set.UpdateSetMembers([]string{oracle}, "add"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []string
+	}{
+		{map[string]interface{}{}, []string{"oracle", "root", "sa", "svc_backup"}},
+		{map[string]interface{}{"host_id": "s1"}, []string{"oracle", "root"}},
+		{map[string]interface{}{"domain_id": "d1"}, []string{"svc_backup"}},
+		{map[string]interface{}{"credential_type": "Password", "managed": true}, []string{"root", "svc_backup"}},
+		{map[string]interface{}{"managed": false}, []string{"oracle", "sa"}},
+		{map[string]interface{}{"is_admin_account": true}, []string{"root"}},
+		{map[string]interface{}{"is_admin_account": false, "host_id": "s1"}, []string{"oracle"}},
+		{map[string]interface{}{"set_id": set.ID}, []string{"oracle"}},
+		{map[string]interface{}{"cloudprovider_id": "c1"}, nil},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceAccounts().Schema, c.config)
+		if err := dataSourceAccountsRead(d, meta); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.config, err)
+		}
+		var names []string
+		for _, a := range d.Get("accounts").([]interface{}) {
+			names = append(names, a.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%v: expected %v, got %v", c.config, c.names, names)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAccounts().Schema, map[string]interface{}{"set_id": set.ID})
+	if err := dataSourceAccountsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"id":               oracle,
+		"name":             "oracle",
+		"host_id":          "s1",
+		"domain_id":        "",
+		"database_id":      "",
+		"cloudprovider_id": "",
+		"credential_type":  "SshKey",
+		"managed":          false,
+		"is_admin_account": false,
+	}
+	if got := d.Get("accounts.0"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
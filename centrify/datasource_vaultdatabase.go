@@ -7,9 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/databaseclass"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceDatabase_deprecated() *schema.Resource {
@@ -132,8 +130,8 @@ func getDSDatabaseSchema() map[string]*schema.Schema {
 }
 
 func dataSourceDatabaseRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding database")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding database")
+	client := m.(*providerMeta).client
 	object := vault.NewDatabase(client)
 	object.Name = d.Get("name").(string)
 	object.FQDN = d.Get("hostname").(string)
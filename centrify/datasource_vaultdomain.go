@@ -5,9 +5,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceDomain_deprecated() *schema.Resource {
@@ -190,8 +188,8 @@ func getDSDomainSchema() map[string]*schema.Schema {
 }
 
 func dataSourceDomainRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding domain")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding domain")
+	client := m.(*providerMeta).client
 	object := vault.NewDomain(client)
 	object.Name = d.Get("name").(string)
 
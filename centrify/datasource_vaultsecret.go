@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceSecret_deprecated() *schema.Resource {
@@ -80,8 +78,8 @@ func getDSSecretSchema() map[string]*schema.Schema {
 }
 
 func dataSourceSecretRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding vault secret")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding vault secret")
+	client := m.(*providerMeta).client
 	object := vault.NewSecret(client)
 	object.SecretName = d.Get("secret_name").(string)
 	if v, ok := d.GetOk("parent_path"); ok {
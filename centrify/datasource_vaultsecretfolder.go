@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceSecretFolder_deprecated() *schema.Resource {
@@ -58,8 +56,8 @@ func getDSSecretFolderSchema() map[string]*schema.Schema {
 }
 
 func dataSourceSecretFolderRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding SecretFolder")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding SecretFolder")
+	client := m.(*providerMeta).client
 	object := vault.NewSecretFolder(client)
 	object.Name = d.Get("name").(string)
 	if v, ok := d.GetOk("parent_path"); ok {
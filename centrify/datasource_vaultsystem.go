@@ -7,9 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/computerclass"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceSystem_deprecated() *schema.Resource {
@@ -284,8 +282,8 @@ func getDSSystemSchema() map[string]*schema.Schema {
 }
 
 func dataSourceSystemRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding system")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding system")
+	client := m.(*providerMeta).client
 	object := vault.NewSystem(client)
 	object.Name = d.Get("name").(string)
 	object.FQDN = d.Get("fqdn").(string)
@@ -0,0 +1,150 @@
+package centrify
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/marcozj/golang-sdk/enum/computerclass"
+	"github.com/marcozj/golang-sdk/enum/managementmode"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/hashcode"
+)
+
+func dataSourceSystems() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSystemsRead,
+
+		Schema: map[string]*schema.Schema{
+			"computer_class": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return systems of this type",
+				ValidateFunc: validation.StringInSlice([]string{
+					computerclass.Windows.String(),
+					computerclass.Unix.String(),
+					computerclass.CiscoAsyncOS.String(),
+					computerclass.CiscoIOS.String(),
+					computerclass.CiscoNXOS.String(),
+					computerclass.JuniperJunos.String(),
+					computerclass.HPNonStop.String(),
+					computerclass.IBMi.String(),
+					computerclass.CheckPointGaia.String(),
+					computerclass.PaloAltoPANOS.String(),
+					computerclass.F5BIGIP.String(),
+					computerclass.VMwareVMkernel.String(),
+					computerclass.GenericSSH.String(),
+					computerclass.CustomSSH.String(),
+				}, false),
+			},
+			"domain_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return systems joined to this domain",
+			},
+			"management_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return systems with this management mode",
+				ValidateFunc: validation.StringInSlice([]string{
+					managementmode.Unknown.String(),
+					managementmode.RPCOverTCP.String(),
+					managementmode.SMB.String(),
+					managementmode.WinRMOverHTTP.String(),
+					managementmode.WinRMOverHTTPS.String(),
+					managementmode.Disabled.String(),
+				}, false),
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return systems whose name matches this regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"set_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return systems that are members of this set",
+			},
+			"systems": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Systems that match all filters, ordered by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fqdn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"computer_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSystemsRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Finding systems")
+	client := m.(*providerMeta).client
+
+	var conditions redrockConditions
+	conditions.equals("ComputerClass", d.Get("computer_class").(string))
+	conditions.equals("DomainId", d.Get("domain_id").(string))
+	conditions.equals("ManagementMode", d.Get("management_mode").(string))
+	query := "SELECT ID, Name, FQDN, ComputerClass FROM Server" + conditions.where()
+
+	rows, err := redrockQueryPages(client, query, nil, 0)
+	if err != nil {
+		return fmt.Errorf(" Error finding systems: %v", err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	var members map[string]bool
+	if v, ok := d.GetOk("set_id"); ok {
+		if members, err = setMembers(client, v.(string)); err != nil {
+			return fmt.Errorf(" Error reading members of set %s: %v", v.(string), err)
+		}
+	}
+
+	systems := []interface{}{}
+	for _, row := range rows {
+		id, name := stringValue(row["ID"]), stringValue(row["Name"])
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		if members != nil && !members[id] {
+			continue
+		}
+		systems = append(systems, map[string]interface{}{
+			"id":             id,
+			"name":           name,
+			"fqdn":           stringValue(row["FQDN"]),
+			"computer_class": stringValue(row["ComputerClass"]),
+		})
+	}
+	sort.SliceStable(systems, func(i, j int) bool {
+		return systems[i].(map[string]interface{})["name"].(string) < systems[j].(map[string]interface{})["name"].(string)
+	})
+	logFor(m).Debugf("Found %d systems", len(systems))
+
+	d.SetId(hashcode.Strings([]string{query, d.Get("name_regex").(string), d.Get("set_id").(string)}))
+	d.Set("systems", systems)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package centrify
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func TestDataSourceSystemsRead(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	server.Insert("Server", map[string]interface{}{"Name": "web02", "FQDN": "web02.corp.local", "ComputerClass": "Unix", "DomainId": "d1"})
+	web01 := server.Insert("Server", map[string]interface{}{"Name": "web01", "FQDN": "web01.corp.local", "ComputerClass": "Unix", "DomainId": "d1"})
+	server.Insert("Server", map[string]interface{}{"Name": "db01", "FQDN": "db01.corp.local", "ComputerClass": "Unix", "DomainId": "d2"})
+	server.Insert("Server", map[string]interface{}{"Name": "dc01", "FQDN": "dc01.corp.local", "ComputerClass": "Windows", "DomainId": "d1", "ManagementMode": "Smb"})
+
+	set := vault.NewManualSet(meta.client)
+	set.ID = server.Insert("Sets", map[string]interface{}{"Name": "Web Servers", "ObjectType": "Server", "CollectionType": "ManualBucket"})
+	set.ObjectType = "Server"
+	if _, err := set.UpdateSetMembers([]string{web01}, "add"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []string
+	}{
+		{map[string]interface{}{}, []string{"db01", "dc01", "web01", "web02"}},
+		{map[string]interface{}{"computer_class": "Unix", "domain_id": "d1"}, []string{"web01", "web02"}},
+		{map[string]interface{}{"management_mode": "Smb"}, []string{"dc01"}},
+		{map[string]interface{}{"name_regex": "^web"}, []string{"web01", "web02"}},
+		{map[string]interface{}{"computer_class": "Unix", "set_id": set.ID}, []string{"web01"}},
+		{map[string]interface{}{"computer_class": "CiscoIOS"}, nil},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceSystems().Schema, c.config)
+		if err := dataSourceSystemsRead(d, meta); err != nil {
+			t.Fatalf("%v: unexpected error: %v", c.config, err)
+		}
+		var names []string
+		for _, s := range d.Get("systems").([]interface{}) {
+			names = append(names, s.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("%v: expected %v, got %v", c.config, c.names, names)
+		}
+		if d.Id() == "" {
+			t.Errorf("%v: expected ID to be set", c.config)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSystems().Schema, map[string]interface{}{"name_regex": "^web01$"})
+	if err := dataSourceSystemsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := d.Get("systems.0.id"); got != web01 {
+		t.Errorf("expected id %s, got %v", web01, got)
+	}
+	if got := d.Get("systems.0.fqdn"); got != "web01.corp.local" {
+		t.Errorf("expected fqdn web01.corp.local, got %v", got)
+	}
+}
+
+func TestDataSourceSystemsRead_paging(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	server.SetQueryLimit(2)
+	count := 3
+	for i := 0; i < count; i++ {
+		server.Insert("Server", map[string]interface{}{"Name": fmt.Sprintf("host%d", i), "ComputerClass": "Unix"})
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSystems().Schema, map[string]interface{}{})
+	if err := dataSourceSystemsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := len(d.Get("systems").([]interface{})); got != count {
+		t.Errorf("Expected all %d systems, got %d", count, got)
+	}
+}
+
+func TestRedrockQueryPages_pageNumberIgnored(t *testing.T) {
+	// Tenant that returns the same full page for every page number and no FullCount
+	var page bytes.Buffer
+	page.WriteString(`{"success":true,"Result":{"Results":[`)
+	for i := 0; i < redrockPageSize; i++ {
+		if i > 0 {
+			page.WriteString(",")
+		}
+		fmt.Fprintf(&page, `{"Row":{"ID":"system-%d"}}`, i)
+	}
+	page.WriteString(`]}}`)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(page.Bytes())
+	}))
+	defer server.Close()
+	client := &restapi.RestClient{Service: server.URL, Client: server.Client(), Headers: map[string]string{}}
+
+	_, err := redrockQueryPages(client, "SELECT ID FROM Server", nil, 0)
+	if err == nil || !strings.Contains(err.Error(), "same rows for page 2") {
+		t.Errorf("Expected repeated page to fail query, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected paging to stop at repeated page, made %d calls", calls)
+	}
+}
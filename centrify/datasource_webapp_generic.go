@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceGenericWebApp_deprecated() *schema.Resource {
@@ -152,8 +150,8 @@ func getDSGenericWebAppSchema() map[string]*schema.Schema {
 }
 
 func dataSourceGenericWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Generic webapp")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Generic webapp")
+	client := m.(*providerMeta).client
 	object := vault.NewGenericWebApp(client)
 	object.Name = d.Get("name").(string)
 
@@ -5,9 +5,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceOauthWebApp_deprecated() *schema.Resource {
@@ -64,8 +62,8 @@ func getDSOauthWebAppSchema() map[string]*schema.Schema {
 }
 
 func dataSourceOauthWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Oauth webapp")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Oauth webapp")
+	client := m.(*providerMeta).client
 	object := vault.NewOauthWebApp(client)
 	object.Name = d.Get("name").(string)
 	object.ApplicationID = d.Get("application_id").(string)
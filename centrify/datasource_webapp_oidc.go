@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceOidcWebApp_deprecated() *schema.Resource {
@@ -92,8 +90,8 @@ func getDSOidcWebAppSchema() map[string]*schema.Schema {
 }
 
 func dataSourceOidcWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Oidc webapp")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Oidc webapp")
+	client := m.(*providerMeta).client
 	object := vault.NewOidcWebApp(client)
 	object.Name = d.Get("name").(string)
 	object.ApplicationID = d.Get("application_id").(string)
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func dataSourceSamlWebApp_deprecated() *schema.Resource {
@@ -161,8 +159,8 @@ func getDSSamlWebAppSchema() map[string]*schema.Schema {
 }
 
 func dataSourceSamlWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Finding Saml webapp")
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Finding Saml webapp")
+	client := m.(*providerMeta).client
 	object := vault.NewSamlWebApp(client)
 	object.Name = d.Get("name").(string)
 	if v, ok := d.GetOk("application_id"); ok {
@@ -0,0 +1,92 @@
+package centrify
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// notFoundMessages are fragments of error messages, lower cased, of tenant API responses and golang-sdk meaning
+// that requested object doesn't exist. golang-sdk returns tenant errors as plain text "<message> <exception>" so
+// this is the only way to tell them apart.
+var notFoundMessages = []string{
+	"not exist",
+	"not found",
+	"cannot find",
+	"could not find",
+	"no longer exists",
+	// Tenant exception types, e.g. "Centrify.Cloud.Core.ObjectNotFoundException"
+	"notfoundexception",
+}
+
+// notFoundExclusions match golang-sdk errors, lower cased, that contain not found message but are about something
+// missing other than the requested object
+var notFoundExclusions = []*regexp.Regexp{
+	regexp.MustCompile(`^ssh key id not found for account `),
+	regexp.MustCompile(`^policy \S+ not found in policy list$`),
+}
+
+// notFoundError - error returned when object doesn't exist in tenant
+type notFoundError struct {
+	err error
+}
+
+func (e *notFoundError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notFoundError) Unwrap() error {
+	return e.err
+}
+
+// classifyError returns notFoundError if error returned by golang-sdk means that object doesn't exist,
+// otherwise the error as is
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var nf *notFoundError
+	if errors.As(err, &nf) {
+		return err
+	}
+	// HTTP errors, such as 404 of a proxy, and transport errors say nothing about the object
+	var httpErr *restapi.HttpError
+	var urlErr *url.Error
+	if errors.As(err, &httpErr) || errors.As(err, &urlErr) {
+		return err
+	}
+	msg := strings.ToLower(strings.TrimSpace(err.Error()))
+	for _, p := range notFoundExclusions {
+		if p.MatchString(msg) {
+			return err
+		}
+	}
+	for _, m := range notFoundMessages {
+		if strings.Contains(msg, m) {
+			return &notFoundError{err: err}
+		}
+	}
+	return err
+}
+
+// isNotFound reports whether error means that object doesn't exist in tenant
+func isNotFound(err error) bool {
+	var nf *notFoundError
+	return errors.As(classifyError(err), &nf)
+}
+
+// removeIfNotFound removes resource from state with a warning if read error means that object was deleted
+// outside of Terraform, so that Terraform plans to create it again instead of failing. Objects that were
+// just created are expected to exist so the error is reported instead.
+func removeIfNotFound(d *schema.ResourceData, m interface{}, err error) bool {
+	if !isNotFound(err) || d.IsNewResource() {
+		return false
+	}
+	logFor(m).Infof("Object %s no longer exists, removing it from state: %v", d.Id(), err)
+	d.SetId("")
+	return true
+}
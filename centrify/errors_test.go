@@ -0,0 +1,83 @@
+package centrify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func TestIsNotFound(t *testing.T) {
+	cases := map[string]bool{
+		"Secret not found <nil>":                                true,
+		"The requested resource does not exist.":                true,
+		"Cannot find role with ID 1234":                         true,
+		"Role with ID 1234 does not exist <nil>":                true,
+		"Federated group Contractors does not exist <nil>":      true,
+		"Authentication (login or challenge) has failed. <nil>": false,
+		"Missing ID for *platform.Secret":                       false,
+		"System does not exist in tenant":                       true,
+		"Failed Centrify.Cloud.Core.ObjectNotFoundException":    true,
+		"SSH Key ID not found for account admin":                false,
+		"Policy /Policy/Default not found in policy list":       false,
+		"Query returns 0 object":                                false,
+	}
+	for msg, expected := range cases {
+		if got := isNotFound(errors.New(msg)); got != expected {
+			t.Errorf("isNotFound(%q) = %v, want %v", msg, got, expected)
+		}
+	}
+	if isNotFound(nil) {
+		t.Error("Expected nil error not to be not found")
+	}
+	if !isNotFound(fmt.Errorf(" Error reading Secret: %w", classifyError(errors.New("Secret not found")))) {
+		t.Error("Expected wrapped not found error to be not found")
+	}
+	httpErr := &restapi.HttpError{StatusCode: http.StatusNotFound}
+	if isNotFound(httpErr) {
+		t.Error("Expected HTTP 404 not to mean that object doesn't exist")
+	}
+	urlErr := &url.Error{Op: "Post", URL: "https://tenant/ServerManage/GetSecret", Err: errors.New("Secret not found")}
+	if isNotFound(urlErr) {
+		t.Error("Expected transport error not to mean that object doesn't exist")
+	}
+}
+
+func TestResourceRead_notFound(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	r := Provider().ResourcesMap["centrify_secret"]
+	d := r.TestResourceData()
+	d.SetId("00000000-0000-0000-0000-000000000000")
+	if err := r.Read(d, meta); err != nil {
+		t.Fatalf("Expected secret deleted outside of Terraform to be removed from state, got %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected secret to be removed from state, ID is %s", d.Id())
+	}
+
+	// Object that was just created must exist
+	d = r.TestResourceData()
+	d.SetId("00000000-0000-0000-0000-000000000000")
+	d.MarkNewResource()
+	if err := r.Read(d, meta); err == nil {
+		t.Error("Expected error reading secret that was just created")
+	}
+
+	// Other errors fail the read and keep the resource in state
+	config := testMockTokenConfig(server)
+	config.Token = "invalid"
+	meta = testMockClient(t, config)
+	d = r.TestResourceData()
+	d.SetId("00000000-0000-0000-0000-000000000000")
+	if err := r.Read(d, meta); err == nil {
+		t.Error("Expected error reading secret with rejected token")
+	}
+	if d.Id() == "" {
+		t.Error("Expected resource to stay in state when read fails")
+	}
+}
@@ -0,0 +1,457 @@
+package centrify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	logger "github.com/marcozj/golang-sdk/logging"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// exportObject is an object found in tenant that is exported as a resource
+type exportObject struct {
+	id   string
+	name string
+	// parent is ID of the object that the object belongs to, such as system of an account
+	parent string
+}
+
+// exportSource lists objects of a resource type in tenant
+type exportSource struct {
+	resource string
+	list     func(client *restapi.RestClient) ([]exportObject, error)
+}
+
+// exportSources are resource types that can be exported. Objects referred to by others come first so that
+// the generated configuration reads top down.
+var exportSources = []exportSource{
+	{"centrify_authenticationprofile", listAuthenticationProfiles},
+	{"centrify_passwordprofile", listPasswordProfiles},
+	{"centrify_user", listRedrock("SELECT ID, Username FROM User", "ID", "Username")},
+	{"centrify_role", listRedrock("SELECT ID, Name FROM Role", "ID", "Name")},
+	{"centrify_manualset", listRedrock("SELECT ID, Name FROM Sets WHERE CollectionType='ManualBucket'", "ID", "Name")},
+	{"centrify_domain", listRedrock("SELECT ID, Name FROM VaultDomain", "ID", "Name")},
+	{"centrify_system", listRedrock("SELECT ID, Name FROM Server", "ID", "Name")},
+	{"centrify_database", listRedrock("SELECT ID, Name FROM VaultDatabase", "ID", "Name")},
+	{"centrify_account", listRedrock("SELECT ID, User, Host, DomainID, DatabaseID FROM VaultAccount", "ID", "User", "Host", "DomainID", "DatabaseID")},
+	{"centrify_secretfolder", listRedrock("SELECT ID, Name FROM Sets WHERE ObjectType='DataVault' AND CollectionType='Phantom'", "ID", "Name")},
+	{"centrify_secret", listRedrock("SELECT ID, SecretName FROM DataVault", "ID", "SecretName")},
+	{"centrify_policy", listPolicies},
+	{"centrify_webapp_generic", listRedrock("SELECT ID, Name FROM Application WHERE AppType='Web' AND WebAppType='UsernamePassword'", "ID", "Name")},
+	{"centrify_webapp_saml", listRedrock("SELECT ID, Name FROM Application WHERE AppType='Web' AND WebAppType='Saml'", "ID", "Name")},
+	{"centrify_webapp_oauth", listRedrock("SELECT ID, Name FROM Application WHERE AppType='Web' AND WebAppType='OAuth'", "ID", "Name")},
+	{"centrify_webapp_oidc", listRedrock("SELECT ID, Name FROM Application WHERE AppType='Web' AND WebAppType='OpenIDConnect'", "ID", "Name")},
+}
+
+// ExportTypes returns resource types that can be exported
+func ExportTypes() []string {
+	var types []string
+	for _, s := range exportSources {
+		types = append(types, s.resource)
+	}
+	return types
+}
+
+// ExportResult is the result of Export
+type ExportResult struct {
+	// Config is Terraform configuration of exported objects
+	Config []byte
+	// ImportScript is shell script that imports exported objects into Terraform state
+	ImportScript []byte
+	// Resources is number of exported objects
+	Resources int
+	// Warnings lists objects or resource types that couldn't be exported
+	Warnings []string
+}
+
+// exportedResource is an object read from tenant by its resource
+type exportedResource struct {
+	resource string
+	label    string
+	data     *schema.ResourceData
+}
+
+// Export reads objects of given resource types, or all types returned by ExportTypes if none is given, from tenant
+// and generates Terraform configuration of matching centrify_* resources together with script that imports them.
+// IDs of exported objects found in attributes are replaced by references to their resources. Objects that can't be
+// listed or read are skipped with a warning, but export fails if tenant doesn't return all objects of a type.
+func Export(meta interface{}, types []string) (*ExportResult, error) {
+	pm, ok := meta.(*providerMeta)
+	if !ok {
+		return nil, fmt.Errorf(" Export requires configured provider")
+	}
+	client := pm.client
+	for _, t := range types {
+		if !contains(ExportTypes(), t) {
+			return nil, fmt.Errorf(" Resource type %s can't be exported. Supported types are %s", t, strings.Join(ExportTypes(), ", "))
+		}
+	}
+
+	result := &ExportResult{}
+	provider := Provider()
+
+	// Find all objects first so that their IDs can be replaced by references regardless of order
+	labels := make(map[string]bool)
+	refs := make(map[string]string)
+	var objects []exportedResource
+	var ids []string
+	for _, source := range exportSources {
+		if len(types) > 0 && !contains(types, source.resource) {
+			continue
+		}
+		found, err := source.list(client)
+		if err != nil {
+			// Exporting only some of the objects would go unnoticed
+			var truncated *truncatedQueryError
+			if errors.As(err, &truncated) {
+				return nil, fmt.Errorf(" Error listing %s: %v", source.resource, err)
+			}
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Skipped %s: %v", source.resource, err))
+			continue
+		}
+		sort.SliceStable(found, func(i, j int) bool { return found[i].name < found[j].name })
+		for _, o := range found {
+			name := o.name
+			if ref, ok := refs[o.parent]; ok {
+				// Name accounts after their system, domain or database
+				name += "_" + strings.Split(ref, ".")[1]
+			}
+			label := uniqueLabel(labels, source.resource, exportLabel(name))
+			refs[o.id] = source.resource + "." + label + ".id"
+			objects = append(objects, exportedResource{resource: source.resource, label: label})
+			ids = append(ids, o.id)
+		}
+	}
+
+	var exported []exportedResource
+	for i, o := range objects {
+		r := provider.ResourcesMap[o.resource]
+		d := r.Data(nil)
+		d.SetId(ids[i])
+		logger.Infof("Exporting %s %s", o.resource, ids[i])
+		if err := r.Read(d, meta); err != nil || d.Id() == "" {
+			if err == nil {
+				err = fmt.Errorf("object no longer exists")
+			}
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Skipped %s %s: %v", o.resource, ids[i], err))
+			delete(refs, ids[i])
+			continue
+		}
+		o.data = d
+		exported = append(exported, o)
+	}
+
+	var config, script strings.Builder
+	var variables []string
+	config.WriteString("# Generated by terraform-provider-centrify export\n")
+	script.WriteString("#!/bin/sh\n# Generated by terraform-provider-centrify export. Run it in directory of the generated configuration.\nset -e\n\n")
+	for _, o := range exported {
+		w := &hclWriter{refs: refs, self: o.data.Id(), variablePrefix: strings.TrimPrefix(o.resource, "centrify_") + "_" + o.label}
+		w.writeBlock(fmt.Sprintf("resource %q %q", o.resource, o.label), provider.ResourcesMap[o.resource].Schema, o.data.Get, 0, "")
+		config.WriteString("\n" + w.String())
+		variables = append(variables, w.variables...)
+		script.WriteString(fmt.Sprintf("terraform import %s %s\n", shellQuote(o.resource+"."+o.label), shellQuote(o.data.Id())))
+	}
+	for _, v := range variables {
+		config.WriteString(fmt.Sprintf("\nvariable %q {\n  type        = string\n  description = \"Sensitive value that can't be read from tenant\"\n}\n", v))
+	}
+
+	result.Config = []byte(config.String())
+	result.ImportScript = []byte(script.String())
+	result.Resources = len(exported)
+	return result, nil
+}
+
+// listRedrock returns function that lists objects with Redrock query. Parent is taken from the first of
+// parentColumns that has a value.
+func listRedrock(query, idColumn, nameColumn string, parentColumns ...string) func(client *restapi.RestClient) ([]exportObject, error) {
+	return func(client *restapi.RestClient) ([]exportObject, error) {
+		rows, err := redrockQueryPages(client, query, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		var objects []exportObject
+		for _, row := range rows {
+			o := exportObject{id: stringValue(row[idColumn]), name: stringValue(row[nameColumn])}
+			for _, c := range parentColumns {
+				if o.parent = stringValue(row[c]); o.parent != "" {
+					break
+				}
+			}
+			if o.id != "" {
+				objects = append(objects, o)
+			}
+		}
+		return objects, nil
+	}
+}
+
+func listPolicies(client *restapi.RestClient) ([]exportObject, error) {
+	resp, err := client.CallGenericMapAPI("/Policy/GetNicePlinks", map[string]interface{}{"Args": map[string]interface{}{"Caching": -1}})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+	}
+	results, _ := resp.Result["Results"].([]interface{})
+	var objects []exportObject
+	for _, r := range results {
+		result, _ := r.(map[string]interface{})
+		row, _ := result["Row"].(map[string]interface{})
+		if id := stringValue(row["ID"]); id != "" {
+			objects = append(objects, exportObject{id: id, name: strings.TrimPrefix(id, "/Policy/")})
+		}
+	}
+	return objects, nil
+}
+
+func listAuthenticationProfiles(client *restapi.RestClient) ([]exportObject, error) {
+	resp, err := client.CallSliceAPI("/AuthProfile/GetProfileList", map[string]interface{}{"Args": map[string]interface{}{"Caching": -1}})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+	}
+	var objects []exportObject
+	for _, r := range resp.Result {
+		item, _ := r.(map[string]interface{})
+		if id := stringValue(item["Uuid"]); id != "" {
+			objects = append(objects, exportObject{id: id, name: stringValue(item["Name"])})
+		}
+	}
+	return objects, nil
+}
+
+// listPasswordProfiles lists user defined password profiles, built-in profiles can't be managed
+func listPasswordProfiles(client *restapi.RestClient) ([]exportObject, error) {
+	resp, err := client.CallGenericMapAPI("/ServerManage/GetPasswordProfiles", map[string]interface{}{"ProfileTypes": "All", "Args": map[string]interface{}{"Caching": -1}})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+	}
+	results, _ := resp.Result["Results"].([]interface{})
+	var objects []exportObject
+	for _, r := range results {
+		result, _ := r.(map[string]interface{})
+		row, _ := result["Row"].(map[string]interface{})
+		if id := stringValue(row["ID"]); id != "" && row["ProfileType"] == "UserDefined" {
+			objects = append(objects, exportObject{id: id, name: stringValue(row["Name"])})
+		}
+	}
+	return objects, nil
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+var labelInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// exportLabel turns object name into resource name, which must start with a letter or underscore and may only
+// contain letters, digits, underscores and dashes
+func exportLabel(name string) string {
+	label := strings.Trim(labelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if label == "" {
+		return "object"
+	}
+	if label[0] >= '0' && label[0] <= '9' || label[0] == '-' {
+		label = "_" + label
+	}
+	return label
+}
+
+// uniqueLabel appends a number to label if resource type already has resource of the same name
+func uniqueLabel(used map[string]bool, resource, label string) string {
+	unique := label
+	for i := 2; used[resource+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[resource+"."+unique] = true
+	return unique
+}
+
+// shellQuote quotes string for POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hclWriter renders attributes of a resource as HCL
+type hclWriter struct {
+	strings.Builder
+	// refs maps IDs of exported objects to references to their resources
+	refs map[string]string
+	// self is ID of the resource being written, which must not refer to itself
+	self string
+	// variablePrefix and variables are used for required sensitive attributes, whose value can't be read
+	variablePrefix string
+	variables      []string
+}
+
+// writeBlock writes block with attributes of schema whose value differs from default. Computed only and deprecated
+// attributes are left out.
+func (w *hclWriter) writeBlock(header string, s map[string]*schema.Schema, get func(string) interface{}, indent int, path string) {
+	pad := strings.Repeat("  ", indent)
+	w.WriteString(pad + header + " {\n")
+
+	var keys []string
+	for k, v := range s {
+		if (v.Optional || v.Required) && v.Deprecated == "" {
+			keys = append(keys, k)
+		}
+	}
+	// Required attributes first
+	sort.Slice(keys, func(i, j int) bool {
+		if s[keys[i]].Required != s[keys[j]].Required {
+			return s[keys[i]].Required
+		}
+		return keys[i] < keys[j]
+	})
+
+	type attribute struct{ key, value string }
+	var attributes []attribute
+	type block struct {
+		key    string
+		schema map[string]*schema.Schema
+		values []interface{}
+	}
+	var blocks []block
+	width := 0
+	for _, k := range keys {
+		v := get(k)
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		if elem, ok := s[k].Elem.(*schema.Resource); ok {
+			if list, ok := v.([]interface{}); ok && len(list) > 0 {
+				blocks = append(blocks, block{k, elem.Schema, list})
+			}
+			continue
+		}
+
+		var value string
+		switch {
+		case s[k].Sensitive:
+			if !s[k].Required {
+				continue
+			}
+			name := exportLabel(w.variablePrefix + "_" + path + k)
+			w.variables = append(w.variables, name)
+			value = "var." + name
+		case !s[k].Required && isDefaultValue(s[k], v):
+			continue
+		default:
+			value = w.value(v, indent+1)
+		}
+		attributes = append(attributes, attribute{k, value})
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+
+	for _, a := range attributes {
+		w.WriteString(fmt.Sprintf("%s  %-*s = %s\n", pad, width, a.key, a.value))
+	}
+	for _, b := range blocks {
+		for i, v := range b.values {
+			m, _ := v.(map[string]interface{})
+			get := func(k string) interface{} { return m[k] }
+			w.WriteString("\n")
+			w.writeBlock(b.key, b.schema, get, indent+1, fmt.Sprintf("%s%s_%d_", path, b.key, i))
+		}
+	}
+	w.WriteString(pad + "}\n")
+}
+
+// value renders attribute value. Strings that are IDs of exported objects become references.
+func (w *hclWriter) value(v interface{}, indent int) string {
+	switch v := v.(type) {
+	case string:
+		if ref, ok := w.refs[v]; ok && v != w.self {
+			return ref
+		}
+		return hclString(v)
+	case *schema.Set:
+		return w.value(v.List(), indent)
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, w.value(item, indent))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pad := strings.Repeat("  ", indent)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			b.WriteString(fmt.Sprintf("%s  %s = %s\n", pad, hclString(k), w.value(v[k], indent+1)))
+		}
+		b.WriteString(pad + "}")
+		return b.String()
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// isDefaultValue reports whether value equals default of the attribute, or is zero value if there is no default
+func isDefaultValue(s *schema.Schema, v interface{}) bool {
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, v)
+	}
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return reflect.ValueOf(v).IsZero()
+	}
+}
+
+// hclString quotes string as HCL string literal, escaping template sequences so that value is kept as is
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20:
+			b.WriteString(fmt.Sprintf(`\u%04x`, r))
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package centrify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func TestExport(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	userID := server.Insert("User", map[string]interface{}{"Username": "alice@example.com", "Name": "alice@example.com", "Mail": "alice@example.com"})
+	server.Insert("Role", map[string]interface{}{
+		"Name":        "Admins",
+		"Description": "Say \"hi\" ${x}",
+		"Members":     []interface{}{map[string]interface{}{"Guid": userID, "Type": "User", "Name": "alice@example.com"}},
+		"Rights":      map[string]interface{}{},
+	})
+	systemID := server.Insert("Server", map[string]interface{}{"Name": "DC01", "FQDN": "dc01.corp.local", "ComputerClass": "Windows", "SessionType": "Rdp"})
+	server.Insert("VaultAccount", map[string]interface{}{"User": "administrator", "Host": systemID, "CredentialType": "Password", "Status": "Active"})
+
+	result, err := Export(meta, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Resources != 4 {
+		t.Errorf("expected 4 exported resources, got %d", result.Resources)
+	}
+	hcl := string(result.Config)
+	for _, want := range []string{
+		`resource "centrify_user" "alice_example_com" {`,
+		`resource "centrify_role" "admins" {`,
+		`description = "Say \"hi\" $${x}"`,
+		`id   = centrify_user.alice_example_com.id`,
+		`resource "centrify_system" "dc01" {`,
+		`resource "centrify_account" "administrator_dc01" {`,
+		`host_id         = centrify_system.dc01.id`,
+	} {
+		if !strings.Contains(hcl, want) {
+			t.Errorf("expected configuration to contain %s, got:\n%s", want, hcl)
+		}
+	}
+	if strings.Contains(hcl, "status") {
+		t.Errorf("expected computed attributes to be left out, got:\n%s", hcl)
+	}
+	if want := "terraform import 'centrify_system.dc01' '" + systemID + "'\n"; !strings.Contains(string(result.ImportScript), want) {
+		t.Errorf("expected import script to contain %s, got:\n%s", want, result.ImportScript)
+	}
+
+	result, err = Export(meta, []string{"centrify_system"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Resources != 1 {
+		t.Errorf("expected 1 exported resource, got %d", result.Resources)
+	}
+	if _, err := Export(meta, []string{"centrify_globalworkflow"}); err == nil {
+		t.Errorf("expected error exporting unsupported type")
+	}
+}
+
+func TestExportLabel(t *testing.T) {
+	cases := map[string]string{
+		"DC01":                 "dc01",
+		"alice@example.com":    "alice_example_com",
+		"System Administrator": "system_administrator",
+		"1st server":           "_1st_server",
+		"!!!":                  "object",
+	}
+	for name, want := range cases {
+		if got := exportLabel(name); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+
+	used := map[string]bool{}
+	for _, want := range []string{"dc01", "dc01_2", "dc01_3"} {
+		if got := uniqueLabel(used, "centrify_system", "dc01"); got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestExport_truncated(t *testing.T) {
+	// Tenant that returns fewer rows than the query matches
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"Result":{"Results":[{"Row":{"ID":"role-1","Name":"Role 1"}}],"FullCount":2}}`))
+	}))
+	defer server.Close()
+	meta := &providerMeta{client: &restapi.RestClient{Service: server.URL, Client: server.Client(), Headers: map[string]string{}}}
+
+	_, err := Export(meta, []string{"centrify_role"})
+	if err == nil || !strings.Contains(err.Error(), "only 1 of 2 rows") {
+		t.Errorf("Expected export to fail when objects are truncated, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package centrify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// importKeys holds key value pairs of human-readable import ID, for example name=DC01 or account=administrator@host=DC01
+type importKeys map[string]string
+
+// importKeyRegex matches key of a key=value pair of import ID
+var importKeyRegex = regexp.MustCompile(`^([a-z_]+)=(.*)$`)
+
+// importIDLookup finds canonical ID of an object from human-readable import ID
+type importIDLookup func(client *restapi.RestClient, keys importKeys) (string, error)
+
+// importStateByName returns importer that accepts either canonical ID of object or human-readable import ID made of
+// one or more of allowed keys joined by @. The latter is resolved by lookup so that state always holds canonical ID.
+func importStateByName(lookup importIDLookup, allowed ...string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if !strings.Contains(d.Id(), "=") {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		keys, err := parseImportID(d.Id(), allowed)
+		if err != nil {
+			return nil, err
+		}
+		id, err := lookup(m.(*providerMeta).client, keys)
+		if err != nil {
+			return nil, fmt.Errorf(" Error finding object %s to import: %v", d.Id(), err)
+		}
+		logFor(m).Infof("Import ID %s is resolved to %s", d.Id(), id)
+		d.SetId(id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// parseImportID splits import ID into key value pairs. Values may contain @, such as user names, so @ only starts
+// a new pair if it is followed by one of allowed keys.
+func parseImportID(id string, allowed []string) (importKeys, error) {
+	keys := importKeys{}
+	key := ""
+	for _, part := range strings.Split(id, "@") {
+		if match := importKeyRegex.FindStringSubmatch(part); match != nil {
+			if !contains(allowed, match[1]) {
+				return nil, fmt.Errorf(" Invalid import ID %s, key %s is not supported. Supported keys are %s", id, match[1], strings.Join(allowed, ", "))
+			}
+			if _, ok := keys[match[1]]; ok {
+				return nil, fmt.Errorf(" Invalid import ID %s, key %s is given more than once", id, match[1])
+			}
+			key = match[1]
+			keys[key] = match[2]
+			continue
+		}
+		if key == "" {
+			return nil, fmt.Errorf(" Invalid import ID %s, expected key=value pairs joined by @. Supported keys are %s", id, strings.Join(allowed, ", "))
+		}
+		keys[key] += "@" + part
+	}
+
+	return keys, nil
+}
+
+// splitImportPath splits path of a folder or secret such as Folder1/Folder2/secret1 into parent path, as stored by
+// tenant with backslash separators, and name
+func splitImportPath(path string) (string, string) {
+	path = strings.Trim(strings.ReplaceAll(path, "/", "\\"), "\\")
+	if i := strings.LastIndex(path, "\\"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return "", path
+}
+
+// queryResultID returns ID of object found by Query of golang-sdk
+func queryResultID(result map[string]interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	id, ok := result["ID"].(string)
+	if !ok || id == "" {
+		return "", fmt.Errorf(" Object found has no ID")
+	}
+	return id, nil
+}
+
+// importSingleton returns importer of a resource that manages tenant wide settings and so always has the same ID.
+// Besides the ID itself, any of aliases is accepted.
+func importSingleton(id string, aliases ...string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if d.Id() != id && !contains(aliases, d.Id()) {
+			return nil, fmt.Errorf(" Invalid import ID %s, expected %s", d.Id(), strings.Join(append(aliases, id), " or "))
+		}
+		d.SetId(id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
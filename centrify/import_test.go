@@ -0,0 +1,159 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	cases := []struct {
+		id      string
+		allowed []string
+		want    importKeys
+	}{
+		{"name=DC01", []string{"name", "fqdn"}, importKeys{"name": "DC01"}},
+		{"name=DC01@fqdn=dc01.corp.local", []string{"name", "fqdn"}, importKeys{"name": "DC01", "fqdn": "dc01.corp.local"}},
+		{"account=administrator@host=DC01", []string{"account", "host"}, importKeys{"account": "administrator", "host": "DC01"}},
+		{"account=admin@corp.local@host=DC01", []string{"account", "host"}, importKeys{"account": "admin@corp.local", "host": "DC01"}},
+		{"name=admin@corp.local", []string{"name"}, importKeys{"name": "admin@corp.local"}},
+		{"path=Folder1/Folder2/secret1", []string{"path"}, importKeys{"path": "Folder1/Folder2/secret1"}},
+	}
+	for _, c := range cases {
+		got, err := parseImportID(c.id, c.allowed)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.id, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: expected %v, got %v", c.id, c.want, got)
+		}
+	}
+
+	for _, id := range []string{"nmae=DC01", "name=DC01@name=DC02", "DC01@name=DC01", "name=DC01@hots=DC02"} {
+		if _, err := parseImportID(id, []string{"name", "host"}); err == nil {
+			t.Errorf("%s: expected invalid import ID error", id)
+		}
+	}
+}
+
+func TestSplitImportPath(t *testing.T) {
+	cases := map[string][2]string{
+		"secret1":                   {"", "secret1"},
+		"Folder1/secret1":           {"Folder1", "secret1"},
+		"/Folder1/Folder2/secret1":  {"Folder1\\Folder2", "secret1"},
+		"Folder1\\Folder2\\secret1": {"Folder1\\Folder2", "secret1"},
+	}
+	for path, want := range cases {
+		if parent, name := splitImportPath(path); parent != want[0] || name != want[1] {
+			t.Errorf("%s: expected %q %q, got %q %q", path, want[0], want[1], parent, name)
+		}
+	}
+}
+
+func TestImportStateByName(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	systemID := server.Insert("Server", map[string]interface{}{"Name": "DC01", "FQDN": "dc01.corp.local", "ComputerClass": "Windows"})
+	accountID := server.Insert("VaultAccount", map[string]interface{}{"User": "administrator", "Host": systemID})
+	secretID := server.Insert("DataVault", map[string]interface{}{"SecretName": "secret1", "ParentPath": "Folder1\\Folder2"})
+
+	cases := []struct {
+		resource string
+		id       string
+		want     string
+	}{
+		{"centrify_system", "name=DC01", systemID},
+		{"centrify_system", "fqdn=dc01.corp.local", systemID},
+		{"centrify_system", systemID, systemID},
+		{"centrify_account", "account=administrator@host=DC01", accountID},
+		{"centrify_secret", "path=Folder1/Folder2/secret1", secretID},
+	}
+	for _, c := range cases {
+		r := Provider().ResourcesMap[c.resource]
+		d := r.TestResourceData()
+		d.SetId(c.id)
+		if _, err := r.Importer.State(d, meta); err != nil {
+			t.Errorf("%s %s: unexpected error: %v", c.resource, c.id, err)
+			continue
+		}
+		if d.Id() != c.want {
+			t.Errorf("%s %s: expected ID %s, got %s", c.resource, c.id, c.want, d.Id())
+		}
+	}
+
+	domainID := server.Insert("VaultDomain", map[string]interface{}{"Name": "corp.local"})
+	r := Provider().ResourcesMap["centrify_domainconfiguration"]
+	d := r.TestResourceData()
+	d.SetId("name=corp.local")
+	if _, err := r.Importer.State(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Id() != domainID+"-configuration" || d.Get("domain_id").(string) != domainID {
+		t.Errorf("Expected domain configuration of %s, got ID %s and domain_id %s", domainID, d.Id(), d.Get("domain_id"))
+	}
+
+	r = Provider().ResourcesMap["centrify_system"]
+	d = r.TestResourceData()
+	d.SetId("name=DC02")
+	if _, err := r.Importer.State(d, meta); err == nil {
+		t.Error("Expected import of missing system to fail")
+	}
+}
+
+func TestImportSingleton(t *testing.T) {
+	cases := []struct {
+		resource string
+		id       string
+		want     string
+	}{
+		{"centrify_policyorder", "centrify_policy_links", policyLinksID},
+		{"centrify_policyorder", policyLinksID, policyLinksID},
+		{"centrify_globalgroupmappings", "centrify_global_group_mappings", groupMappingsID},
+		{"centrify_globalworkflow", "secretsWorkflow", globalWorkflowIDPrefix + "secretsWorkflow"},
+		{"centrify_globalworkflow", globalWorkflowIDPrefix + "wf", globalWorkflowIDPrefix + "wf"},
+	}
+	for _, c := range cases {
+		r := Provider().ResourcesMap[c.resource]
+		d := r.TestResourceData()
+		d.SetId(c.id)
+		if _, err := r.Importer.State(d, nil); err != nil {
+			t.Errorf("%s %s: unexpected error: %v", c.resource, c.id, err)
+			continue
+		}
+		if d.Id() != c.want {
+			t.Errorf("%s %s: expected ID %s, got %s", c.resource, c.id, c.want, d.Id())
+		}
+	}
+
+	r := Provider().ResourcesMap["centrify_globalworkflow"]
+	d := r.TestResourceData()
+	d.SetId("accountWorkflow")
+	if _, err := r.Importer.State(d, nil); err == nil {
+		t.Error("Expected import of unknown workflow type to fail")
+	}
+	r = Provider().ResourcesMap["centrify_policyorder"]
+	d = r.TestResourceData()
+	d.SetId("policy_order")
+	if _, err := r.Importer.State(d, nil); err == nil {
+		t.Error("Expected import of policy order with unexpected ID to fail")
+	}
+}
+
+func TestImportRoleMembership(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+	roleID := testSeedRole(server)
+
+	for _, id := range []string{roleID, "name=Existing Role"} {
+		r := Provider().ResourcesMap["centrify_role_membership"]
+		d := r.TestResourceData()
+		d.SetId(id)
+		if _, err := r.Importer.State(d, meta); err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if d.Id() != roleID || d.Get("role_id").(string) != roleID {
+			t.Errorf("%s: expected membership of role %s, got ID %s and role_id %s", id, roleID, d.Id(), d.Get("role_id"))
+		}
+	}
+}
@@ -0,0 +1,702 @@
+package mocktenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redrock table names used by the platform package
+const (
+	tableUser         = "User"
+	tableRole         = "Role"
+	tableServer       = "Server"
+	tableVaultAccount = "VaultAccount"
+	tableDataVault    = "DataVault"
+	tableSets         = "Sets"
+	tableAccessKeys   = "AccessKeys"
+	tableCheckouts    = "Checkouts"
+	tableDirectory    = "DirectoryObjects"
+	tableDirServices  = "DirectoryServices"
+)
+
+// adminRights is the list of administrative rights returned by get_superrights.js
+var adminRights = map[string]string{
+	"Admin Portal Login":              "/lib/rights/adminportal.json",
+	"User Management":                 "/lib/rights/usermgmt.json",
+	"Role Management":                 "/lib/rights/rolemgmt.json",
+	"Privileged Access Service Power": "/lib/rights/pas_power.json",
+}
+
+func (s *Server) registerHandlers() {
+	s.handlers = map[string]handlerFunc{
+		"/redrock/query": s.redrockQuery,
+
+		// User
+		"/cdirectoryservice/createuser":         s.createUser,
+		"/usermgmt/getuserattributes":           s.readUser,
+		"/cdirectoryservice/changeuser":         s.updateUser,
+		"/usermgmt/removeuser":                  s.deleteUser,
+		"/usermgmt/resetuserpassword":           s.resetUserPassword,
+		"/saasmanage/storerole":                 s.createRole,
+		"/saasmanage/getrole":                   s.readRole,
+		"/roles/updaterole":                     s.updateRole,
+		"/saasmanage/deleterole":                s.deleteRole,
+		"/saasmanage/getrolemembers":            s.readRoleMembers,
+		"/core/getassignedadministrativerights": s.readRoleRights,
+		"/saasmanage/assignsuperrights":         s.assignRoleRights(true),
+		"/saasmanage/unassignsuperrights":       s.assignRoleRights(false),
+		"/usermgmt/directoryservicequery":       s.directoryServiceQuery,
+		"/core/getdirectoryservices":            s.getDirectoryServices,
+
+		// System
+		"/servermanage/addresource":                         s.create(tableServer),
+		"/servermanage/updateresource":                      s.updateBy(tableServer, "System"),
+		"/servermanage/deleteresource":                      s.deleteBy(tableServer, "System"),
+		"/servermanage/getcomputerchallenges":               s.subset(tableServer, "System", "LoginDefaultProfile", "LoginRules"),
+		"/privilegeelevation/getchallenges":                 s.subset(tableServer, "System", "PrivilegeElevationDefaultProfile", "PrivilegeElevationRules"),
+		"/servermanage/getagentauthworkflowconfig":          s.subset(tableServer, "System", "AgentAuthWorkflowEnabled", "AgentAuthWorkflowApprovers"),
+		"/servermanage/getprivilegeelevationworkflowconfig": s.subset(tableServer, "System", "PrivilegeElevationWorkflowEnabled", "PrivilegeElevationWorkflowApprovers"),
+		"/servermanage/setresourcepermissions":              s.setPermissions(tableServer, "System"),
+
+		// Account
+		"/servermanage/addaccount":                s.create(tableVaultAccount),
+		"/servermanage/getallaccountinformation":  s.readAccount,
+		"/servermanage/updateaccount":             s.updateBy(tableVaultAccount, "Account"),
+		"/servermanage/deleteaccount":             s.deleteBy(tableVaultAccount, "Account"),
+		"/servermanage/getaccountchallenges":      s.subset(tableVaultAccount, "Account", "PasswordCheckoutDefaultProfile", "PasswordCheckoutRules", "AccessSecretCheckoutDefaultProfile", "AccessSecretCheckoutRules"),
+		"/servermanage/setaccountpermissions":     s.setPermissions(tableVaultAccount, "Account"),
+		"/servermanage/updatepassword":            s.updateAccountPassword,
+		"/servermanage/checkoutpassword":          s.checkoutPassword,
+		"/servermanage/checkinpassword":           s.checkinPassword,
+		"/servermanage/setadministrativeaccounts": s.setAdminAccount,
+		"/aws/getaccesskeys":                      s.readAccessKeys,
+		"/aws/verifyaccesskeyforuseraccount":      s.verifyAccessKey,
+		"/aws/addaccesskey":                       s.addAccessKey,
+		"/aws/deleteaccesskey":                    s.deleteAccessKey,
+		"/aws/retrieveaccesskey":                  s.retrieveAccessKey,
+
+		// Secret
+		"/servermanage/addsecret":                    s.create(tableDataVault),
+		"/servermanage/getsecret":                    s.readBy(tableDataVault, "Secret"),
+		"/servermanage/updatesecret":                 s.updateBy(tableDataVault, "Secret"),
+		"/servermanage/deletesecret":                 s.deleteBy(tableDataVault, "Secret"),
+		"/servermanage/getsecretrightsandchallenges": s.readSecretChallenges,
+		"/servermanage/setsecretpermissions":         s.setPermissions(tableDataVault, "Secret"),
+		"/servermanage/retrievesecretcontents":       s.retrieveSecret,
+
+		// Set
+		"/collection/createmanualcollection":   s.create(tableSets),
+		"/collection/getcollection":            s.readBy(tableSets, "Set"),
+		"/collection/updatecollection":         s.updateBy(tableSets, "Set"),
+		"/collection/deletecollection":         s.deleteBy(tableSets, "Set"),
+		"/collection/updatememberscollection":  s.updateSetMembers,
+		"/collection/getmembers":               s.readSetMembers,
+		"/collection/setcollectionpermissions": s.setPermissions(tableSets, "Set"),
+
+		// Policy
+		"/policy/getniceplinks":     s.readPlinks,
+		"/policy/savepolicyblock3":  s.savePolicy,
+		"/policy/getpolicyblock":    s.readPolicy,
+		"/policy/deletepolicyblock": s.deletePolicy,
+	}
+}
+
+// create returns a handler that stores the request as a new row and returns its ID as string result
+func (s *Server) create(table string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		row := without(args, "updateChallenges", "WorkflowSent")
+		delete(row, "ID")
+		id := s.insert(table, row)
+		return success(id)
+	}
+}
+
+// readBy returns a handler that returns the row identified by ID argument
+func (s *Server) readBy(table, kind string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		row, found := s.row(table, stringArg(args, "ID"))
+		if !found {
+			return notFound(kind)
+		}
+		return success(public(row))
+	}
+}
+
+// updateBy returns a handler that merges request attributes into the row identified by ID argument
+func (s *Server) updateBy(table, kind string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		if !s.update(table, stringArg(args, "ID"), without(args, "updateChallenges", "WorkflowSent")) {
+			return notFound(kind)
+		}
+		return success(nil)
+	}
+}
+
+// deleteBy returns a handler that removes the row identified by ID argument
+func (s *Server) deleteBy(table, kind string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		if !s.remove(table, stringArg(args, "ID")) {
+			return notFound(kind)
+		}
+		return success(true)
+	}
+}
+
+// subset returns a handler that returns selected attributes of the row identified by ID argument
+func (s *Server) subset(table, kind string, keys ...string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		row, found := s.row(table, stringArg(args, "ID"))
+		if !found {
+			return notFound(kind)
+		}
+		result := make(map[string]interface{})
+		for _, k := range keys {
+			if v, ok := row[k]; ok {
+				result[k] = v
+			}
+		}
+		return success(result)
+	}
+}
+
+func (s *Server) setPermissions(table, kind string) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		row, found := s.row(table, stringArg(args, "ID"))
+		if !found {
+			return notFound(kind)
+		}
+		row["Grants"] = args["Grants"]
+		return success(nil)
+	}
+}
+
+/*
+	User
+*/
+
+func (s *Server) createUser(args map[string]interface{}, body []byte) response {
+	name := stringArg(args, "Name")
+	for _, row := range s.tables[tableUser] {
+		if row["Username"] == name {
+			return failure("The user name %s is already in use.", name)
+		}
+	}
+	row := without(args, "confirmPassword")
+	delete(row, "ID")
+	row["Username"] = name
+	return success(s.insert(tableUser, row))
+}
+
+func (s *Server) readUser(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableUser, stringArg(args, "ID"))
+	if !found {
+		return notFound("User")
+	}
+	return success(public(row))
+}
+
+func (s *Server) updateUser(args map[string]interface{}, body []byte) response {
+	row := without(args, "Password", "confirmPassword")
+	if name, ok := row["Name"]; ok {
+		row["Username"] = name
+	}
+	if !s.update(tableUser, stringArg(args, "ID"), row) {
+		return notFound("User")
+	}
+	return success(nil)
+}
+
+func (s *Server) deleteUser(args map[string]interface{}, body []byte) response {
+	if !s.remove(tableUser, stringArg(args, "ID")) {
+		return notFound("User")
+	}
+	return success(nil)
+}
+
+func (s *Server) resetUserPassword(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableUser, stringArg(args, "ID"))
+	if !found {
+		return notFound("User")
+	}
+	row["Password"] = args["newPassword"]
+	return success(true)
+}
+
+/*
+	Role
+*/
+
+func (s *Server) createRole(args map[string]interface{}, body []byte) response {
+	name := stringArg(args, "Name")
+	for _, row := range s.tables[tableRole] {
+		if row["Name"] == name {
+			return failure("Role %s already exists.", name)
+		}
+	}
+	id := s.insert(tableRole, map[string]interface{}{
+		"Name":        name,
+		"Description": args["Description"],
+		"Members":     []interface{}{},
+		"Rights":      map[string]interface{}{},
+	})
+	return success(map[string]interface{}{"_RowKey": id})
+}
+
+func (s *Server) readRole(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableRole, stringArg(args, "name"))
+	if !found {
+		return notFound("Role")
+	}
+	return success(without(row, "Members", "Rights"))
+}
+
+func (s *Server) updateRole(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableRole, stringArg(args, "Name"))
+	if !found {
+		return notFound("Role")
+	}
+	if v, ok := args["NewName"]; ok {
+		row["Name"] = v
+	}
+	if v, ok := args["Description"]; ok {
+		row["Description"] = v
+	}
+
+	members := row["Members"].([]interface{})
+	for key, memberType := range map[string]string{"Users": "User", "Roles": "Role", "Groups": "Group"} {
+		change, ok := args[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, id := range toStrings(change["Add"]) {
+			members = append(members, map[string]interface{}{
+				"Guid": id,
+				"Name": s.principalName(memberType, id),
+				"Type": memberType,
+			})
+		}
+		for _, id := range toStrings(change["Delete"]) {
+			var kept []interface{}
+			for _, m := range members {
+				if m.(map[string]interface{})["Guid"] != id {
+					kept = append(kept, m)
+				}
+			}
+			members = kept
+		}
+	}
+	if members == nil {
+		members = []interface{}{}
+	}
+	row["Members"] = members
+
+	return success(nil)
+}
+
+func (s *Server) deleteRole(args map[string]interface{}, body []byte) response {
+	if !s.remove(tableRole, stringArg(args, "Name")) {
+		return notFound("Role")
+	}
+	return success(nil)
+}
+
+func (s *Server) readRoleMembers(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableRole, stringArg(args, "name"))
+	if !found {
+		return notFound("Role")
+	}
+	var rows []map[string]interface{}
+	for _, m := range row["Members"].([]interface{}) {
+		rows = append(rows, m.(map[string]interface{}))
+	}
+	return success(resultSet(rows))
+}
+
+func (s *Server) readRoleRights(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableRole, stringArg(args, "role"))
+	if !found {
+		return notFound("Role")
+	}
+	var rows []map[string]interface{}
+	for desc, path := range row["Rights"].(map[string]interface{}) {
+		rows = append(rows, map[string]interface{}{"Description": desc, "Path": path})
+	}
+	return success(resultSet(rows))
+}
+
+// assignRoleRights handles AssignSuperRights and UnAssignSuperRights which take a list of {Role, Path}
+func (s *Server) assignRoleRights(assign bool) handlerFunc {
+	return func(args map[string]interface{}, body []byte) response {
+		var items []map[string]interface{}
+		if err := json.Unmarshal(body, &items); err != nil {
+			return failure(err.Error())
+		}
+		for _, item := range items {
+			row, found := s.row(tableRole, stringArg(item, "Role"))
+			if !found {
+				return notFound("Role")
+			}
+			rights := row["Rights"].(map[string]interface{})
+			for desc, path := range adminRights {
+				if path != item["Path"] {
+					continue
+				}
+				if assign {
+					rights[desc] = path
+				} else {
+					delete(rights, desc)
+				}
+			}
+		}
+		return success(nil)
+	}
+}
+
+func (s *Server) principalName(memberType, id string) string {
+	switch memberType {
+	case "User":
+		if row, ok := s.row(tableUser, id); ok {
+			return fmt.Sprintf("%v", row["Username"])
+		}
+	case "Role":
+		if row, ok := s.row(tableRole, id); ok {
+			return fmt.Sprintf("%v", row["Name"])
+		}
+	}
+	return id
+}
+
+// likeRegex extracts the pattern of the first _like operator of a directory service query filter
+var likeRegex = regexp.MustCompile(`"_like":"((?:[^"\\]|\\.)*)"`)
+
+// directoryServiceQuery emulates /UserMgmt/DirectoryServiceQuery for users and groups stored in DirectoryObjects
+// table. Only the _like pattern of the filter is honoured and it matches anywhere in SystemName, ignoring case.
+// getDirectoryServices lists rows of DirectoryServices table keyed by directoryServiceUuid
+func (s *Server) getDirectoryServices(args map[string]interface{}, body []byte) response {
+	var rows []map[string]interface{}
+	for _, row := range sortedRows(s.tables[tableDirServices]) {
+		row = public(row)
+		row["directoryServiceUuid"] = row["ID"]
+		rows = append(rows, row)
+	}
+	return success(resultSet(rows))
+}
+
+func (s *Server) directoryServiceQuery(args map[string]interface{}, body []byte) response {
+	services := make(map[string]bool)
+	list, _ := args["directoryServices"].([]interface{})
+	for _, v := range list {
+		services[fmt.Sprintf("%v", v)] = true
+	}
+	result := make(map[string]interface{})
+	for key, objectType := range map[string]string{"user": "User", "group": "Group"} {
+		filter := stringArg(args, key)
+		if filter == "" {
+			continue
+		}
+		var pattern string
+		if match := likeRegex.FindStringSubmatch(filter); match != nil {
+			pattern = strings.ToLower(match[1])
+		}
+		var rows []map[string]interface{}
+		for _, row := range sortedRows(s.tables[tableDirectory]) {
+			if row["ObjectType"] != objectType || !services[stringArg(row, "DirectoryServiceUuid")] {
+				continue
+			}
+			if !strings.Contains(strings.ToLower(stringArg(row, "SystemName")), pattern) {
+				continue
+			}
+			row = public(row)
+			row["InternalName"] = row["ID"]
+			rows = append(rows, row)
+		}
+		result[objectType] = resultSet(rows)
+	}
+	return success(result)
+}
+
+/*
+	Account
+*/
+
+func (s *Server) readAccount(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableVaultAccount, stringArg(args, "ID"))
+	if !found {
+		return notFound("Account")
+	}
+	return success(map[string]interface{}{
+		"VaultAccount": map[string]interface{}{"Row": public(row)},
+	})
+}
+
+func (s *Server) updateAccountPassword(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableVaultAccount, stringArg(args, "ID"))
+	if !found {
+		return notFound("Account")
+	}
+	row["Password"] = args["Password"]
+	return success(true)
+}
+
+func (s *Server) checkoutPassword(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableVaultAccount, stringArg(args, "ID"))
+	if !found {
+		return notFound("Account")
+	}
+	coid := s.insert(tableCheckouts, map[string]interface{}{"AccountID": row["ID"]})
+	result := map[string]interface{}{
+		"Password": row["Password"],
+		"COID":     coid,
+	}
+	// Lifetime is only known if account sets it, as tenant checkout policy isn't emulated
+	if lifetime, ok := row["DefaultCheckoutTime"]; ok {
+		result["Lifetime"] = lifetime
+	}
+	return success(result)
+}
+
+func (s *Server) checkinPassword(args map[string]interface{}, body []byte) response {
+	if !s.remove(tableCheckouts, stringArg(args, "ID")) {
+		return notFound("Checkout")
+	}
+	return success(true)
+}
+
+func (s *Server) setAdminAccount(args map[string]interface{}, body []byte) response {
+	for _, id := range toStrings(args["Systems"]) {
+		if row, found := s.row(tableServer, id); found {
+			row["AdminAccountId"] = args["PVID"]
+		}
+	}
+	if id := stringArg(args, "PVID"); id != "" {
+		if row, found := s.row(tableVaultAccount, id); found {
+			row["IsAdminAccount"] = true
+		}
+	}
+	return success(nil)
+}
+
+func (s *Server) readAccessKeys(args map[string]interface{}, body []byte) response {
+	id := stringArg(args, "ID")
+	keys := []interface{}{}
+	for _, row := range sortedRows(s.tables[tableAccessKeys]) {
+		if row["AccountId"] == id {
+			keys = append(keys, without(public(row), "AccountId"))
+		}
+	}
+	return success(keys)
+}
+
+func (s *Server) verifyAccessKey(args map[string]interface{}, body []byte) response {
+	if stringArg(args, "AccessKeyId") == "" || stringArg(args, "SecretAccessKey") == "" {
+		return failure("Invalid access key")
+	}
+	return success("")
+}
+
+func (s *Server) addAccessKey(args map[string]interface{}, body []byte) response {
+	if _, found := s.row(tableVaultAccount, stringArg(args, "AccountId")); !found {
+		return notFound("Account")
+	}
+	id := s.insert(tableAccessKeys, map[string]interface{}{
+		"AccountId":       args["AccountId"],
+		"AccessKeyId":     args["AccessKeyId"],
+		"SecretAccessKey": args["SecretAccessKey"],
+	})
+	return success(id)
+}
+
+func (s *Server) deleteAccessKey(args map[string]interface{}, body []byte) response {
+	if !s.remove(tableAccessKeys, stringArg(args, "AccessKeyRowkey")) {
+		return notFound("Access key")
+	}
+	return success("")
+}
+
+func (s *Server) retrieveAccessKey(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableAccessKeys, stringArg(args, "AccessKeyRowkey"))
+	if !found {
+		return notFound("Access key")
+	}
+	return success(map[string]interface{}{"SecretAccessKey": row["SecretAccessKey"]})
+}
+
+/*
+	Secret
+*/
+
+func (s *Server) readSecretChallenges(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableDataVault, stringArg(args, "ID"))
+	if !found {
+		return notFound("Secret")
+	}
+	challenges := make(map[string]interface{})
+	for _, k := range []string{"DataVaultDefaultProfile", "DataVaultRules"} {
+		if v, ok := row[k]; ok && v != nil {
+			challenges[k] = v
+		}
+	}
+	return success(map[string]interface{}{"Challenges": challenges})
+}
+
+func (s *Server) retrieveSecret(args map[string]interface{}, body []byte) response {
+	row, found := s.row(tableDataVault, stringArg(args, "ID"))
+	if !found {
+		return notFound("Secret")
+	}
+	return success(map[string]interface{}{"SecretText": row["SecretText"]})
+}
+
+/*
+	Set
+*/
+
+func (s *Server) updateSetMembers(args map[string]interface{}, body []byte) response {
+	id := stringArg(args, "id")
+	if _, found := s.row(tableSets, id); !found {
+		return notFound("Set")
+	}
+	for _, m := range toMaps(args["add"]) {
+		s.members[id] = append(s.members[id], stringArg(m, "Key"))
+	}
+	for _, m := range toMaps(args["remove"]) {
+		var kept []string
+		for _, key := range s.members[id] {
+			if key != m["Key"] {
+				kept = append(kept, key)
+			}
+		}
+		s.members[id] = kept
+	}
+	return success("")
+}
+
+func (s *Server) readSetMembers(args map[string]interface{}, body []byte) response {
+	id := stringArg(args, "ID")
+	if _, found := s.row(tableSets, id); !found {
+		return notFound("Set")
+	}
+	members := []interface{}{}
+	for _, key := range s.members[id] {
+		members = append(members, map[string]interface{}{"Key": key})
+	}
+	return success(members)
+}
+
+/*
+	Policy
+*/
+
+func (s *Server) readPlinks(args map[string]interface{}, body []byte) response {
+	result := resultSet(s.plinks)
+	result["RevStamp"] = fmt.Sprintf("%d", s.revision)
+	return success(result)
+}
+
+func (s *Server) savePolicy(args map[string]interface{}, body []byte) response {
+	policy, _ := args["policy"].(map[string]interface{})
+	path := stringArg(policy, "Path")
+	if path == "" {
+		return failure("Policy path is missing")
+	}
+	if policy["Newpolicy"] == true {
+		if _, exists := s.policies[path]; exists {
+			return failure("Policy %s already exists", path)
+		}
+	} else if _, exists := s.policies[path]; !exists {
+		return notFound("Policy")
+	}
+
+	var plinks []map[string]interface{}
+	for _, plink := range toMaps(args["plinks"]) {
+		if plink["ID"] == nil || plink["ID"] == "" {
+			plink["ID"] = plink["PolicySet"]
+		}
+		plinks = append(plinks, plink)
+	}
+	s.plinks = plinks
+	s.revision++
+
+	policy = without(policy, "Newpolicy")
+	policy["RevStamp"] = fmt.Sprintf("%d", s.revision)
+	s.policies[path] = policy
+
+	return success(nil)
+}
+
+func (s *Server) readPolicy(args map[string]interface{}, body []byte) response {
+	policy, found := s.policies[stringArg(args, "name")]
+	if !found {
+		return notFound("Policy")
+	}
+	return success(copyRow(policy))
+}
+
+func (s *Server) deletePolicy(args map[string]interface{}, body []byte) response {
+	path := stringArg(args, "path")
+	if _, found := s.policies[path]; !found {
+		return notFound("Policy")
+	}
+	delete(s.policies, path)
+	var plinks []map[string]interface{}
+	for _, plink := range s.plinks {
+		if plink["ID"] != path {
+			plinks = append(plinks, plink)
+		}
+	}
+	s.plinks = plinks
+	s.revision++
+	return success(nil)
+}
+
+// resultSet wraps rows in the Redrock result format
+func resultSet(rows []map[string]interface{}) map[string]interface{} {
+	results := []interface{}{}
+	for _, row := range rows {
+		results = append(results, map[string]interface{}{"Row": row})
+	}
+	return map[string]interface{}{
+		"Count":     len(results),
+		"FullCount": len(results),
+		"Results":   results,
+	}
+}
+
+// sortedRows returns rows ordered by ID so that responses are deterministic
+func sortedRows(table map[string]map[string]interface{}) []map[string]interface{} {
+	var ids []string
+	for id := range table {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var rows []map[string]interface{}
+	for _, id := range ids {
+		rows = append(rows, table[id])
+	}
+	return rows
+}
+
+func toStrings(v interface{}) []string {
+	var out []string
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func toMaps(v interface{}) []map[string]interface{} {
+	var out []map[string]interface{}
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,359 @@
+// Package mocktenant provides an in-process fake of the Centrify Platform REST API.
+// It implements enough of the OAuth token endpoint, Redrock query service and object
+// CRUD endpoints used by the golang-sdk platform package for the provider tests to run
+// without a live tenant.
+package mocktenant
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Default credentials accepted by the fake tenant
+const (
+	DefaultAppID        = "terraform"
+	DefaultScope        = "all"
+	DefaultClientID     = "admin@example.com"
+	DefaultClientSecret = "Passw0rd!"
+)
+
+// Server - In-process fake Centrify tenant
+type Server struct {
+	*httptest.Server
+
+	AppID        string
+	Scope        string
+	ClientID     string
+	ClientSecret string
+	// Token is a pre-issued access token that is always accepted
+	Token string
+
+	mu       sync.Mutex
+	tokens   map[string]bool
+	issued   int
+	tables   map[string]map[string]map[string]interface{}
+	members  map[string][]string
+	plinks   []map[string]interface{}
+	policies map[string]map[string]interface{}
+	revision int
+	handlers map[string]handlerFunc
+	// lag is number of times a new object is not found by read APIs, hidden counts remaining misses by ID
+	lag    int
+	hidden map[string]int
+	// queryLimit is number of rows returned by Redrock query that doesn't ask for a page
+	queryLimit int
+}
+
+type handlerFunc func(args map[string]interface{}, body []byte) response
+
+// response represents the standard Centrify API response envelope
+type response struct {
+	Success   bool        `json:"success"`
+	Result    interface{} `json:"Result"`
+	Message   interface{} `json:"Message"`
+	Exception interface{} `json:"Exception"`
+}
+
+// NewServer starts a fake tenant listening on a local TLS endpoint.
+// Caller must call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		AppID:        DefaultAppID,
+		Scope:        DefaultScope,
+		ClientID:     DefaultClientID,
+		ClientSecret: DefaultClientSecret,
+		Token:        newID(),
+		tokens:       make(map[string]bool),
+		tables:       make(map[string]map[string]map[string]interface{}),
+		members:      make(map[string][]string),
+		policies:     make(map[string]map[string]interface{}),
+		hidden:       make(map[string]int),
+	}
+	s.registerHandlers()
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Rows returns a copy of all rows stored in a Redrock table
+func (s *Server) Rows(table string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []map[string]interface{}
+	for _, row := range s.tables[table] {
+		rows = append(rows, copyRow(row))
+	}
+	return rows
+}
+
+// Insert adds a row into a Redrock table and returns its ID. It is used to seed objects
+// that the provider doesn't create itself, such as directory users or connectors.
+func (s *Server) Insert(table string, row map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.insert(table, row)
+	delete(s.hidden, id)
+	return id
+}
+
+// SetVisibilityLag makes objects created afterwards through the API not found by the first n read calls,
+// as if the tenant hasn't indexed them yet
+func (s *Server) SetVisibilityLag(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lag = n
+}
+
+// SetQueryLimit makes Redrock queries that don't ask for a page return only the first n rows, as tenant does
+func (s *Server) SetQueryLimit(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queryLimit = n
+}
+
+// RevokeTokens invalidates all access tokens issued by the token endpoint, as if they had expired
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = make(map[string]bool)
+}
+
+// TokensIssued returns the number of access tokens issued by the token endpoint
+func (s *Server) TokensIssued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issued
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	path := strings.ToLower(r.URL.Path)
+
+	if strings.HasPrefix(path, "/oauth2/token/") {
+		s.serveToken(w, r, strings.TrimPrefix(r.URL.Path, "/oauth2/token/"), body)
+		return
+	}
+
+	if !s.authorized(r.Header.Get("Authorization")) {
+		w.WriteHeader(http.StatusUnauthorized)
+		writeJSON(w, response{Success: false, Message: "Authentication (login or challenge) has failed."})
+		return
+	}
+
+	handler, ok := s.handlers[path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, response{Success: false, Message: fmt.Sprintf("Unknown API %s", r.URL.Path)})
+		return
+	}
+
+	var args map[string]interface{}
+	if len(body) > 0 && body[0] == '{' {
+		if err := json.Unmarshal(body, &args); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, response{Success: false, Message: err.Error()})
+			return
+		}
+	}
+
+	s.mu.Lock()
+	resp, hidden := s.hiddenRead(path, args)
+	if !hidden {
+		resp = handler(args, body)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, resp)
+}
+
+// serveToken implements OAuth2 client credentials flow of /oauth2/token/<appid>
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request, appID string, body []byte) {
+	form, _ := url.ParseQuery(string(body))
+	fail := func(code, desc string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": desc})
+	}
+
+	if appID != s.AppID {
+		fail("invalid_client", fmt.Sprintf("Unknown application %s", appID))
+		return
+	}
+	if form.Get("grant_type") != "client_credentials" {
+		fail("unsupported_grant_type", "Only client_credentials grant is supported")
+		return
+	}
+	if form.Get("scope") != s.Scope {
+		fail("invalid_scope", fmt.Sprintf("Scope %s is not allowed", form.Get("scope")))
+		return
+	}
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte(s.ClientID+":"+s.ClientSecret))
+	if r.Header.Get("Authorization") != expected {
+		fail("invalid_client", "Client authentication failed")
+		return
+	}
+
+	token := newID()
+	s.mu.Lock()
+	s.tokens[token] = true
+	s.issued++
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+func (s *Server) authorized(header string) bool {
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == "" || token == header {
+		return false
+	}
+	if token == s.Token {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[token]
+}
+
+// insert stores a row and returns its ID. Caller must hold s.mu.
+func (s *Server) insert(table string, row map[string]interface{}) string {
+	id, _ := row["ID"].(string)
+	if id == "" {
+		id = newUUID()
+	}
+	row = copyRow(row)
+	row["ID"] = id
+	if s.tables[table] == nil {
+		s.tables[table] = make(map[string]map[string]interface{})
+	}
+	s.tables[table][id] = row
+	if s.lag > 0 {
+		s.hidden[id] = s.lag
+	}
+	return id
+}
+
+// hiddenRead returns not found response if a read API is called for an object that isn't visible yet.
+// Caller must hold s.mu.
+func (s *Server) hiddenRead(path string, args map[string]interface{}) (response, bool) {
+	if !strings.HasPrefix(path[strings.LastIndex(path, "/")+1:], "get") {
+		return response{}, false
+	}
+	id := stringArg(args, "ID")
+	if id == "" {
+		id = stringArg(args, "name")
+	}
+	if s.hidden[id] == 0 {
+		return response{}, false
+	}
+	s.hidden[id]--
+	return notFound("Object"), true
+}
+
+func (s *Server) row(table, id string) (map[string]interface{}, bool) {
+	row, ok := s.tables[table][id]
+	return row, ok
+}
+
+func (s *Server) update(table, id string, args map[string]interface{}) bool {
+	row, ok := s.row(table, id)
+	if !ok {
+		return false
+	}
+	for k, v := range args {
+		row[k] = v
+	}
+	row["ID"] = id
+	return true
+}
+
+func (s *Server) remove(table, id string) bool {
+	if _, ok := s.row(table, id); !ok {
+		return false
+	}
+	delete(s.tables[table], id)
+	return true
+}
+
+func success(result interface{}) response {
+	return response{Success: true, Result: result}
+}
+
+func notFound(kind string) response {
+	return response{Success: false, Message: fmt.Sprintf("%s not found", kind)}
+}
+
+func failure(format string, a ...interface{}) response {
+	return response{Success: false, Message: fmt.Sprintf(format, a...)}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func copyRow(row map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		c[k] = v
+	}
+	return c
+}
+
+// secretColumns are write-only attributes that the tenant never returns in read responses
+var secretColumns = []string{"Password", "confirmPassword", "ProxyUserPassword", "SecretText", "SecretAccessKey"}
+
+// public returns a copy of row without secret attributes
+func public(row map[string]interface{}) map[string]interface{} {
+	return without(row, secretColumns...)
+}
+
+// without returns a copy of row with the given attributes removed
+func without(row map[string]interface{}, keys ...string) map[string]interface{} {
+	c := copyRow(row)
+	for _, k := range keys {
+		delete(c, k)
+	}
+	return c
+}
+
+func stringArg(args map[string]interface{}, key string) string {
+	v, _ := args[key].(string)
+	return v
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,120 @@
+package mocktenant
+
+import (
+	"testing"
+
+	"github.com/marcozj/golang-sdk/oauth"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func newClient(t *testing.T, s *Server) *restapi.RestClient {
+	c := &oauth.OauthClient{
+		Service:        s.URL,
+		AppID:          s.AppID,
+		Scope:          s.Scope,
+		ClientID:       s.ClientID,
+		ClientSecret:   s.ClientSecret,
+		SkipCertVerify: true,
+	}
+	client, err := c.GetClient()
+	if err != nil {
+		t.Fatalf("Failed to get client: %v", err)
+	}
+	return client
+}
+
+func TestTokenRejectsBadCredentials(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := &oauth.OauthClient{
+		Service:        s.URL,
+		AppID:          s.AppID,
+		Scope:          s.Scope,
+		ClientID:       s.ClientID,
+		ClientSecret:   "wrong",
+		SkipCertVerify: true,
+	}
+	if _, err := c.GetClient(); err == nil {
+		t.Fatal("Expected error for bad client secret")
+	}
+}
+
+func TestUserLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	user := vault.NewUser(client)
+	user.Name = "testuser@example.com"
+	user.DisplayName = "Test User"
+	user.Password = "xxxxxxxxxxxx"
+	user.ConfirmPassword = user.Password
+	if _, err := user.Create(); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	read := vault.NewUser(client)
+	read.ID = user.ID
+	if err := read.Read(); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if read.DisplayName != "Test User" {
+		t.Errorf("DisplayName = %q, want %q", read.DisplayName, "Test User")
+	}
+	if read.Password != "" {
+		t.Error("Password must not be returned by read")
+	}
+
+	if _, err := user.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := read.Read(); err == nil {
+		t.Fatal("Expected error reading deleted user")
+	}
+}
+
+func TestSecretQuery(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	secret := vault.NewSecret(client)
+	secret.SecretName = "mysecret"
+	secret.SecretText = "s3cr3t"
+	secret.Type = "Text"
+	if _, err := secret.Create(); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	lookup := vault.NewSecret(client)
+	lookup.SecretName = "mysecret"
+	id, err := lookup.GetIDByName()
+	if err != nil {
+		t.Fatalf("GetIDByName: %v", err)
+	}
+	if id != secret.ID {
+		t.Errorf("ID = %q, want %q", id, secret.ID)
+	}
+	if len(s.Rows(tableDataVault)) != 1 {
+		t.Errorf("Expected 1 row in %s", tableDataVault)
+	}
+}
+
+func TestRedrockQueryLike(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	s.Insert("Role", map[string]interface{}{"Name": "LAB Admins"})
+	s.Insert("Role", map[string]interface{}{"Name": "Help Desk"})
+
+	results, err := vault.RedRockQuery(client, "SELECT ID, Name FROM Role WHERE Name LIKE 'lab%'", nil)
+	if err != nil {
+		t.Fatalf("RedRockQuery: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 role starting with lab, got %d", len(results))
+	}
+}
@@ -0,0 +1,135 @@
+package mocktenant
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	fromRegex      = regexp.MustCompile(`(?i)\bFROM\s+(\w+)`)
+	whereRegex     = regexp.MustCompile(`(?is)\bWHERE\s+(.*?)(?:\s+ORDER\s+BY\s+.*)?$`)
+	conditionRegex = regexp.MustCompile(`(?i)(?:\w+\.)?(\w+)(\s*=\s*|\s+LIKE\s+)'((?:[^']|'')*)'`)
+)
+
+// redrockQuery emulates /RedRock/query. Only "SELECT * FROM <table> WHERE a='x' AND b LIKE 'y%'" style
+// statements are understood, which is what the platform package issues. Named parameters, referred to
+// as @name, and paging are supported.
+func (s *Server) redrockQuery(args map[string]interface{}, body []byte) response {
+	script := strings.TrimSpace(stringArg(args, "Script"))
+	queryArgs, _ := args["Args"].(map[string]interface{})
+	params := toMaps(queryArgs["Parameters"])
+	// Replace longer names first so that @name doesn't replace beginning of @names
+	sort.Slice(params, func(i, j int) bool { return len(stringArg(params[i], "Name")) > len(stringArg(params[j], "Name")) })
+	for _, p := range params {
+		value := strings.ReplaceAll(stringArg(p, "Value"), "'", "''")
+		script = strings.ReplaceAll(script, "@"+stringArg(p, "Name"), "'"+value+"'")
+	}
+	if strings.HasPrefix(script, "@/lib/get_superrights.js") {
+		var rows []map[string]interface{}
+		for desc, path := range adminRights {
+			rows = append(rows, map[string]interface{}{"Description": desc, "Path": path})
+		}
+		return success(resultSet(rows))
+	}
+
+	match := fromRegex.FindStringSubmatch(script)
+	if match == nil {
+		return failure("Unsupported query: %s", script)
+	}
+	table, ok := s.lookupTable(match[1])
+	if !ok {
+		return success(resultSet(nil))
+	}
+
+	var conditions [][]string
+	if where := whereRegex.FindStringSubmatch(script); where != nil {
+		conditions = conditionRegex.FindAllStringSubmatch(where[1], -1)
+	}
+
+	var rows []map[string]interface{}
+	for _, row := range sortedRows(table) {
+		if matchRow(row, conditions) {
+			rows = append(rows, public(row))
+		}
+	}
+	if size, _ := queryArgs["PageSize"].(float64); size > 0 {
+		page, _ := queryArgs["PageNumber"].(float64)
+		start := int(size) * (int(page) - 1)
+		if start < 0 || start > len(rows) {
+			start = len(rows)
+		}
+		end := start + int(size)
+		if end > len(rows) {
+			end = len(rows)
+		}
+		result := resultSet(rows[start:end])
+		result["FullCount"] = len(rows)
+		return success(result)
+	}
+	if s.queryLimit > 0 && len(rows) > s.queryLimit {
+		result := resultSet(rows[:s.queryLimit])
+		result["FullCount"] = len(rows)
+		return success(result)
+	}
+	return success(resultSet(rows))
+}
+
+// lookupTable finds a table case-insensitively as Redrock table names are not case sensitive
+func (s *Server) lookupTable(name string) (map[string]map[string]interface{}, bool) {
+	for k, v := range s.tables {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func matchRow(row map[string]interface{}, conditions [][]string) bool {
+	for _, c := range conditions {
+		column, value := c[1], strings.ReplaceAll(c[3], "''", "'")
+		if strings.TrimSpace(c[2]) == "=" {
+			if !columnEquals(row, column, value) {
+				return false
+			}
+		} else if !columnLike(row, column, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnLike matches column against LIKE pattern, in which % matches any string and _ any character, ignoring case
+func columnLike(row map[string]interface{}, column, pattern string) bool {
+	var expr strings.Builder
+	expr.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	re := regexp.MustCompile(expr.String())
+	for k, v := range row {
+		if strings.EqualFold(k, column) {
+			return v != nil && re.MatchString(fmt.Sprintf("%v", v))
+		}
+	}
+	return re.MatchString("")
+}
+
+func columnEquals(row map[string]interface{}, column, value string) bool {
+	for k, v := range row {
+		if strings.EqualFold(k, column) {
+			return v != nil && fmt.Sprintf("%v", v) == value
+		}
+	}
+	// Missing column is treated as empty string
+	return value == ""
+}
@@ -0,0 +1,102 @@
+package centrify
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// limitTransport - http.RoundTripper that limits rate and concurrency of API calls. A single instance is
+// shared by all resources and data sources through the client returned by provider configuration.
+type limitTransport struct {
+	base http.RoundTripper
+
+	// interval is minimum time between the start of two requests. Zero means unlimited.
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+
+	// slots limits number of requests in flight. Nil means unlimited.
+	slots chan struct{}
+}
+
+func newLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return base
+	}
+	t := &limitTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+// RoundTrip waits for a free slot and its turn, then sends the request
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-t.slots }) }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if wait := t.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// Request is in flight until its response is read
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// reserve books the next start time and returns how long caller has to wait for it
+func (t *limitTransport) reserve() time.Duration {
+	if t.interval == 0 {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+
+	return wait
+}
+
+// releaseOnClose frees concurrency slot when response body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
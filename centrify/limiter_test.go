@@ -0,0 +1,71 @@
+package centrify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransport_concurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 0, 2)}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Post(server.URL, "application/json", nil)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestLimitTransport_rate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 50, 0)}
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := client.Post(server.URL, "application/json", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	// 6 requests at 50 per second need at least 5 intervals of 20ms
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected requests to be spread over at least 100ms, took %v", elapsed)
+	}
+}
+
+func TestLimitTransport_unlimited(t *testing.T) {
+	if tr := newLimitTransport(http.DefaultTransport, 0, 0); tr != http.DefaultTransport {
+		t.Error("Expected base transport to be used as is when no limit is configured")
+	}
+}
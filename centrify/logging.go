@@ -0,0 +1,381 @@
+package centrify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	logger "github.com/marcozj/golang-sdk/logging"
+)
+
+var (
+	logOutputMu sync.Mutex
+	logFile     *os.File
+	// logOutput is standard log output set by Terraform plugin SDK, used when logpath isn't set
+	logOutput io.Writer
+)
+
+// setupLogOutput directs golang-sdk logger output, which is written through standard log package, to log file
+// if logpath is set. Otherwise standard log output set by Terraform plugin SDK is kept, and only lines written
+// by golang-sdk logger are passed through providerLogWriter. Sensitive values are masked before they are written.
+func setupLogOutput(logPath string, r *redactor) error {
+	logOutputMu.Lock()
+	defer logOutputMu.Unlock()
+
+	switch log.Writer().(type) {
+	case *redactWriter, *providerLogWriter:
+		// Output was set by previous configuration
+	default:
+		logOutput = log.Writer()
+	}
+
+	if logPath != "" {
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf(" Failed to open log file %s: %v", logPath, err)
+		}
+		if logFile != nil {
+			logFile.Close()
+		}
+		logFile = f
+		log.SetOutput(&redactWriter{w: f, redactor: r})
+		return nil
+	}
+
+	if logFile != nil {
+		// Log file was used by previous configuration
+		logFile.Close()
+		logFile = nil
+	}
+	log.SetOutput(&providerLogWriter{w: logOutput, redactor: r})
+
+	return nil
+}
+
+// logLevelFromEnv returns golang-sdk log level from TF_LOG_PROVIDER or TF_LOG environment variable
+func logLevelFromEnv() (logger.Level, bool) {
+	level := os.Getenv("TF_LOG_PROVIDER")
+	if level == "" {
+		level = os.Getenv("TF_LOG")
+	}
+	switch strings.ToUpper(level) {
+	case "":
+		return 0, false
+	case "INFO":
+		return logger.LevelInfo, true
+	case "WARN", "ERROR":
+		return logger.LevelError, true
+	default:
+		// TRACE, DEBUG and any other value enable all logs as Terraform does
+		return logger.LevelDebug, true
+	}
+}
+
+// logLevel is golang-sdk logger level, which golang-sdk doesn't expose, so that operation loggers write lines at
+// the same level
+var logLevel = logger.LevelError
+
+// setLogLevel sets level of golang-sdk logger and operation loggers
+func setLogLevel(level logger.Level) {
+	logLevel = level
+	logger.SetLevel(level)
+}
+
+// logLineRegex matches line written by golang-sdk logger, its level, caller and message, for example
+// "2021/09/07 10:51:59 [INFO ] resource_user.go:143 resourceUserRead(): Reading user"
+var logLineRegex = regexp.MustCompile(`(?s)^(?:\S+ \S+ )?\[(FATAL|ERROR|INFO|DEBUG) *\] (\S+:\d+ \S+\(\)): (.*?)\n?$`)
+
+// logEntry is a log line written by provider
+type logEntry struct {
+	level   logger.Level
+	caller  string
+	message string
+	// tags of the resource operation that writes the line. Empty outside of resource operations.
+	tags logTags
+}
+
+// String returns entry in golang-sdk logger format
+func (e logEntry) String() string {
+	level := "[UNKNOWN]"
+	switch e.level {
+	case logger.LevelFatal:
+		level = "[FATAL]"
+	case logger.LevelError:
+		level = "[ERROR]"
+	case logger.LevelInfo:
+		level = "[INFO ]"
+	case logger.LevelDebug:
+		level = "[DEBUG]"
+	}
+	return fmt.Sprintf("%s %s: %s%s", level, e.caller, e.message, e.tags)
+}
+
+// hclogTimeFormat is timestamp format of JSON log lines that go-plugin parses
+const hclogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// providerLogWriter - io.Writer that writes lines of golang-sdk logger as JSON lines that go-plugin parses into
+// levelled log entries of Terraform, so that they are filtered by TF_LOG and TF_LOG_PROVIDER. Sensitive values
+// are masked. Lines written by Terraform plugin SDK and other packages are written as is.
+type providerLogWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	redactor *redactor
+}
+
+func (w *providerLogWriter) Write(p []byte) (int, error) {
+	m := logLineRegex.FindSubmatch(p)
+	if m == nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, err := w.w.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	entry := logEntry{caller: string(m[2]), message: string(m[3])}
+	switch string(m[1]) {
+	case "FATAL":
+		entry.level = logger.LevelFatal
+	case "ERROR":
+		entry.level = logger.LevelError
+	case "INFO":
+		entry.level = logger.LevelInfo
+	default:
+		entry.level = logger.LevelDebug
+	}
+	if err := w.writeEntry(entry); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *providerLogWriter) writeEntry(e logEntry) error {
+	fields := map[string]interface{}{
+		"@timestamp": time.Now().Format(hclogTimeFormat),
+		"@caller":    e.caller,
+		"@message":   w.redactor.Redact(e.message),
+	}
+	switch e.level {
+	case logger.LevelFatal, logger.LevelError:
+		fields["@level"] = "error"
+	case logger.LevelInfo:
+		fields["@level"] = "info"
+	default:
+		fields["@level"] = "debug"
+	}
+	if e.tags.resourceType != "" {
+		fields["tf_resource_type"] = e.tags.resourceType
+		fields["tf_resource_id"] = e.tags.id()
+		fields["tf_operation"] = e.tags.operation
+	}
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(append(line, '\n'))
+	return err
+}
+
+// writeLogEntry writes entry as structured line if logs go to Terraform, or as golang-sdk logger line otherwise
+func writeLogEntry(e logEntry) {
+	if w, ok := log.Writer().(*providerLogWriter); ok {
+		w.writeEntry(e)
+		return
+	}
+	log.Print(e.String())
+}
+
+// operationLogger writes log lines of a resource operation tagged with resource type, ID and operation. Lines
+// have format and level of golang-sdk logger. Terraform doesn't send resource address to provider, so resource
+// ID identifies the resource.
+type operationLogger struct {
+	tags logTags
+}
+
+// logFor returns logger of the resource operation that is given provider meta m
+func logFor(m interface{}) operationLogger {
+	if meta, ok := m.(*providerMeta); ok {
+		return operationLogger{tags: meta.operation}
+	}
+	return operationLogger{}
+}
+
+// requestLogger returns logger of the resource operation that made API call with the given request
+func requestLogger(req *http.Request) operationLogger {
+	tags, _ := logTagsFromContext(req.Context())
+	return operationLogger{tags: tags}
+}
+
+// Errorf writes line at error level
+func (l operationLogger) Errorf(format string, args ...interface{}) {
+	l.output(logger.LevelError, fmt.Sprintf(format, args...))
+}
+
+// Infof writes line at info level
+func (l operationLogger) Infof(format string, args ...interface{}) {
+	l.output(logger.LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Debugf writes line at debug level
+func (l operationLogger) Debugf(format string, args ...interface{}) {
+	l.output(logger.LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// output writes line with caller of Errorf, Infof or Debugf
+func (l operationLogger) output(level logger.Level, msg string) {
+	if logLevel < level {
+		return
+	}
+	caller := "?:0 ?()"
+	if pc, file, line, ok := runtime.Caller(2); ok {
+		fn := "?"
+		if f := runtime.FuncForPC(pc); f != nil {
+			fn = strings.TrimLeft(filepath.Ext(f.Name()), ".")
+		}
+		caller = fmt.Sprintf("%s:%d %s()", filepath.Base(file), line, fn)
+	}
+	writeLogEntry(logEntry{level: level, caller: caller, message: msg, tags: l.tags})
+}
+
+// logTags identify the resource operation that writes a log line or makes an API call
+type logTags struct {
+	resourceType string
+	operation    string
+	d            *schema.ResourceData
+	schema       map[string]*schema.Schema
+	// deadline of the operation set by resource timeouts. Zero means no deadline.
+	deadline time.Time
+}
+
+func (t logTags) id() string {
+	if t.d == nil || t.d.Id() == "" {
+		return "<new resource>"
+	}
+	return t.d.Id()
+}
+
+// String returns tags in the form appended to log lines, or empty string outside of resource operations
+func (t logTags) String() string {
+	if t.resourceType == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [tf_resource_type=%s tf_resource_id=%s tf_operation=%s]", t.resourceType, t.id(), t.operation)
+}
+
+type logTagsKey struct{}
+
+// logTagsFromContext returns tags of the resource operation that made API call with the given request context
+func logTagsFromContext(ctx context.Context) (logTags, bool) {
+	tags, ok := ctx.Value(logTagsKey{}).(logTags)
+	return tags, ok
+}
+
+// operationTransport - http.RoundTripper of API client given to a single resource operation. It attaches tags of
+// the operation to request context so that transports below can log and audit API calls, and cancels API calls,
+// including their retries and waiting for rate limit, when deadline of the operation has passed.
+type operationTransport struct {
+	base http.RoundTripper
+	tags logTags
+}
+
+func (t *operationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operationLogger{tags: t.tags}.Debugf("Calling %s", req.URL.Path)
+	ctx := context.WithValue(req.Context(), logTagsKey{}, t.tags)
+	if t.tags.deadline.IsZero() {
+		return t.base.RoundTrip(req.WithContext(ctx))
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, t.tags.deadline)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// Context must stay alive until response is read
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: cancel}
+
+	return resp, nil
+}
+
+// forOperation returns copy of provider meta for a resource operation. Its API client is a copy whose transport
+// carries tags and deadline of the operation, so they reach every API call without global state.
+func (m *providerMeta) forOperation(tags logTags) *providerMeta {
+	meta := m.withTransport(&operationTransport{base: m.client.Client.Transport, tags: tags})
+	meta.operation = tags
+	return meta
+}
+
+func (m *providerMeta) withTransport(transport http.RoundTripper) *providerMeta {
+	httpClient := *m.client.Client
+	httpClient.Transport = transport
+	client := *m.client
+	client.Client = &httpClient
+
+	meta := *m
+	meta.client = &client
+	return &meta
+}
+
+// operationMeta returns provider meta for a resource operation, or meta as is if provider isn't configured
+func operationMeta(m interface{}, tags logTags) interface{} {
+	if meta, ok := m.(*providerMeta); ok {
+		return meta.forOperation(tags)
+	}
+	return m
+}
+
+// wrapResourceOperations wraps CRUD functions of a resource or data source so that their API calls are
+// tagged, API calls of create, update and delete are bound by the resource timeouts, and failed create can be
+// rolled back
+func wrapResourceOperations(resourceType string, r *schema.Resource) {
+	wrap := func(operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, m interface{}) error {
+			tags := logTags{resourceType: resourceType, operation: operation, d: d, schema: r.Schema}
+			timeout := operationTimeout(r, d, operation)
+			if timeout > 0 {
+				tags.deadline = time.Now().Add(timeout)
+			}
+
+			err := f(d, operationMeta(m, tags))
+			if err != nil && timeout > 0 && !time.Now().Before(tags.deadline) {
+				return &timeoutError{resourceType: resourceType, operation: operation, timeout: timeout, err: err}
+			}
+			return err
+		}
+	}
+	r.Create = wrap("create", r.Create)
+	r.Read = wrap("read", r.Read)
+	r.Update = wrap("update", r.Update)
+	r.Delete = wrap("delete", r.Delete)
+	r.Create = withRollback(resourceType, r.Create, r.Delete)
+
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, m interface{}) (bool, error) {
+			return exists(d, operationMeta(m, logTags{resourceType: resourceType, operation: "exists", d: d, schema: r.Schema}))
+		}
+	}
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			return state(d, operationMeta(m, logTags{resourceType: resourceType, operation: "import", d: d, schema: r.Schema}))
+		}
+	}
+}
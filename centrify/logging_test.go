@@ -0,0 +1,177 @@
+package centrify
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	logger "github.com/marcozj/golang-sdk/logging"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func TestProviderLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &providerLogWriter{w: &buf, redactor: newRedactor([]string{"password"})}
+
+	w.Write([]byte("2021/09/07 10:51:59 [INFO ] resource_user.go:143 resourceUserCreate(): Creating user map[password:s3cr3t]\n"))
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry["@level"] != "info" || entry["@caller"] != "resource_user.go:143 resourceUserCreate()" || entry["@message"] != "Creating user map[password:"+redactedValue+"]" {
+		t.Errorf("Unexpected log entry %v", entry)
+	}
+	if _, err := time.Parse(hclogTimeFormat, entry["@timestamp"].(string)); err != nil {
+		t.Errorf("Unexpected timestamp: %v", err)
+	}
+
+	// Lines not written by golang-sdk logger are left alone
+	buf.Reset()
+	line := "2021/09/07 10:51:59 [TRACE] terraform: password:keep\n"
+	w.Write([]byte(line))
+	if buf.String() != line {
+		t.Errorf("Expected %q, got %q", line, buf.String())
+	}
+}
+
+func TestOperationLogger(t *testing.T) {
+	out := log.Writer()
+	defer log.SetOutput(out)
+	defer setLogLevel(logger.LevelError)
+
+	var buf bytes.Buffer
+	log.SetOutput(&providerLogWriter{w: &buf, redactor: newRedactor([]string{"password"})})
+	setLogLevel(logger.LevelInfo)
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("1234")
+	meta := &providerMeta{operation: logTags{resourceType: "centrify_user", operation: "update", d: d}}
+	logFor(meta).Infof("Updating user map[password:%s]", "s3cr3t")
+	logFor(meta).Debugf("Not written at info level")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected single JSON line, got %q: %v", buf.String(), err)
+	}
+	expected := map[string]interface{}{
+		"@level":           "info",
+		"@message":         "Updating user map[password:" + redactedValue + "]",
+		"tf_resource_type": "centrify_user",
+		"tf_resource_id":   "1234",
+		"tf_operation":     "update",
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("Expected %s %q, got %q", k, v, entry[k])
+		}
+	}
+	if caller, _ := entry["@caller"].(string); !strings.HasPrefix(caller, "logging_test.go:") || !strings.HasSuffix(caller, " TestOperationLogger()") {
+		t.Errorf("Expected caller in test, got %q", caller)
+	}
+
+	// Log file gets lines in golang-sdk logger format with tags
+	var file bytes.Buffer
+	log.SetOutput(&file)
+	logFor(meta).Infof("Reading user")
+	if !strings.Contains(file.String(), "[INFO ] logging_test.go:") || !strings.HasSuffix(file.String(), "TestOperationLogger(): Reading user [tf_resource_type=centrify_user tf_resource_id=1234 tf_operation=update]\n") {
+		t.Errorf("Unexpected log line %q", file.String())
+	}
+}
+
+func TestSetupLogOutput_noLogPath(t *testing.T) {
+	out := log.Writer()
+	defer log.SetOutput(out)
+	defer setLogLevel(logger.LevelError)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	if err := setupLogOutput("", newRedactor(nil)); err != nil {
+		t.Fatal(err)
+	}
+	// Configuring provider again must not wrap output twice
+	if err := setupLogOutput("", newRedactor(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if w, ok := log.Writer().(*providerLogWriter); !ok || w.w != &buf {
+		t.Fatalf("Expected standard log output to be kept, got %T", log.Writer())
+	}
+
+	setLogLevel(logger.LevelDebug)
+	logger.Debugf("Reading role")
+	log.Printf("[DEBUG] Terraform log line")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"@message":"Reading role"`) || !strings.HasSuffix(lines[1], "[DEBUG] Terraform log line") {
+		t.Errorf("Unexpected log output %q", buf.String())
+	}
+}
+
+func TestWrapResourceOperations_operationMeta(t *testing.T) {
+	var seen logTags
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	base := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		seen, _ = logTagsFromContext(req.Context())
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	meta := &providerMeta{client: &restapi.RestClient{Service: server.URL, Client: base}}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			resp, err := m.(*providerMeta).client.Client.Get(server.URL)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+	}
+	wrapResourceOperations("centrify_user", r)
+	d := r.TestResourceData()
+	d.SetId("1234")
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if seen.resourceType != "centrify_user" || seen.operation != "read" || seen.id() != "1234" {
+		t.Errorf("Expected API call to be tagged with read of centrify_user 1234, got %+v", seen)
+	}
+	// Provider meta shared by operations must not be changed
+	if meta.client.Client != base || meta.operation.resourceType != "" {
+		t.Error("Expected provider meta to be copied for resource operation")
+	}
+}
+
+func TestLogLevelFromEnv(t *testing.T) {
+	defer os.Setenv("TF_LOG", os.Getenv("TF_LOG"))
+	defer os.Setenv("TF_LOG_PROVIDER", os.Getenv("TF_LOG_PROVIDER"))
+
+	os.Setenv("TF_LOG", "")
+	os.Setenv("TF_LOG_PROVIDER", "")
+	if _, ok := logLevelFromEnv(); ok {
+		t.Error("Expected no level without TF_LOG")
+	}
+
+	os.Setenv("TF_LOG", "TRACE")
+	if level, ok := logLevelFromEnv(); !ok || level != logger.LevelDebug {
+		t.Errorf("Expected debug level for TF_LOG=TRACE, got %v", level)
+	}
+
+	os.Setenv("TF_LOG_PROVIDER", "info")
+	if level, ok := logLevelFromEnv(); !ok || level != logger.LevelInfo {
+		t.Errorf("Expected TF_LOG_PROVIDER to take precedence, got %v", level)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,398 @@
+package centrify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const deprecatedTypePrefix = "centrifyvault_"
+
+// replacedTypes are types whose current name can't be derived from their deprecated name
+var replacedTypes = map[string]string{
+	"centrifyvault_vaultdomainreconciliation": "centrify_domainconfiguration",
+	"centrify_domainreconciliation":           "centrify_domainconfiguration",
+}
+
+// StateMigration is the result of MigrateState
+type StateMigration struct {
+	// State is the rewritten state document
+	State []byte
+	// Changes lists migrated resources as "<old address> -> <new address>", or just the address if only attributes
+	// were migrated
+	Changes []string
+}
+
+// MigrateState rewrites Terraform state of format version 4, or `terraform show -json` output, so that resources and
+// data sources of deprecated centrifyvault_* types use their centrify_* names and deprecated attribute layouts are
+// converted to the current ones. Serial of state is incremented so that it can be pushed with `terraform state push`.
+func MigrateState(in []byte) (*StateMigration, error) {
+	decoder := json.NewDecoder(bytes.NewReader(in))
+	// Keep numbers such as serial and attribute values as they are
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf(" Error parsing state: %v", err)
+	}
+
+	provider := Provider()
+	resources := make(map[string]bool)
+	for name := range provider.ResourcesMap {
+		resources[name] = true
+	}
+	dataSources := make(map[string]bool)
+	for name := range provider.DataSourcesMap {
+		dataSources[name] = true
+	}
+	m := &stateMigrator{
+		resources:       provider.ResourcesMap,
+		resourceTypes:   typeMigrations(resources),
+		dataSourceTypes: typeMigrations(dataSources),
+	}
+
+	var err error
+	if _, ok := doc["format_version"]; ok {
+		err = m.migrateShowOutput(doc)
+	} else {
+		err = m.migrateState(doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return &StateMigration{State: out.Bytes(), Changes: m.changes}, nil
+}
+
+// typeMigrations maps deprecated types to current types among types registered in the provider.
+// centrifyvault_vault<name> becomes centrify_<name> and other centrifyvault_<name> become centrify_<name>.
+func typeMigrations(registered map[string]bool) map[string]string {
+	migrations := make(map[string]string)
+	for name := range registered {
+		if !strings.HasPrefix(name, deprecatedTypePrefix) {
+			continue
+		}
+		short := strings.TrimPrefix(name, deprecatedTypePrefix)
+		for _, candidate := range []string{"centrify_" + short, "centrify_" + strings.TrimPrefix(short, "vault")} {
+			if registered[candidate] {
+				migrations[name] = candidate
+				break
+			}
+		}
+	}
+	for from, to := range replacedTypes {
+		if registered[from] {
+			migrations[from] = to
+		}
+	}
+	return migrations
+}
+
+type stateMigrator struct {
+	resources       map[string]*schema.Resource
+	resourceTypes   map[string]string
+	dataSourceTypes map[string]string
+	changes         []string
+}
+
+func (m *stateMigrator) migratedType(mode, typeName string) string {
+	migrations := m.resourceTypes
+	if mode == "data" {
+		migrations = m.dataSourceTypes
+	}
+	if to, ok := migrations[typeName]; ok {
+		return to
+	}
+	return typeName
+}
+
+// migrateState migrates state file of format version 4
+func (m *stateMigrator) migrateState(doc map[string]interface{}) error {
+	if version, _ := doc["version"].(json.Number); version.String() != "4" {
+		return fmt.Errorf(" Unsupported state format version %v, only version 4 written by Terraform 0.12 and later is supported", doc["version"])
+	}
+
+	resources, _ := doc["resources"].([]interface{})
+	addresses := make(map[string]bool)
+	for _, v := range resources {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mode, _ := r["mode"].(string)
+		typeName, _ := r["type"].(string)
+		name, _ := r["name"].(string)
+		module, _ := r["module"].(string)
+
+		newType := m.migratedType(mode, typeName)
+		oldAddress := resourceAddress(module, mode, typeName, name)
+		newAddress := resourceAddress(module, mode, newType, name)
+		if addresses[newAddress] {
+			return fmt.Errorf(" Cannot migrate %s, state already contains %s", oldAddress, newAddress)
+		}
+		addresses[newAddress] = true
+		r["type"] = newType
+
+		attributesMigrated := false
+		instances, _ := r["instances"].([]interface{})
+		for _, i := range instances {
+			instance, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if mode == "managed" {
+				for _, key := range []string{"attributes", "attributes_flat"} {
+					if attrs, ok := instance[key].(map[string]interface{}); ok {
+						version, upgraded, err := m.upgradeAttributes(newType, instance["schema_version"], attrs)
+						if err != nil {
+							return fmt.Errorf(" Error upgrading attributes of %s: %v", oldAddress, err)
+						}
+						if upgraded {
+							instance["schema_version"] = version
+							attributesMigrated = true
+						}
+					}
+				}
+			}
+			if deps, ok := instance["dependencies"].([]interface{}); ok {
+				instance["dependencies"] = m.migrateAddresses(deps)
+			}
+		}
+		m.recordChange(oldAddress, newAddress, attributesMigrated)
+	}
+
+	if len(m.changes) > 0 {
+		serial, _ := doc["serial"].(json.Number)
+		n, _ := serial.Int64()
+		doc["serial"] = n + 1
+	}
+	return nil
+}
+
+// migrateShowOutput migrates output of `terraform show -json`
+func (m *stateMigrator) migrateShowOutput(doc map[string]interface{}) error {
+	values, ok := doc["values"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if module, ok := values["root_module"].(map[string]interface{}); ok {
+		return m.migrateShowModule(module)
+	}
+	return nil
+}
+
+func (m *stateMigrator) migrateShowModule(module map[string]interface{}) error {
+	resources, _ := module["resources"].([]interface{})
+	for _, v := range resources {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mode, _ := r["mode"].(string)
+		typeName, _ := r["type"].(string)
+		address, _ := r["address"].(string)
+
+		newType := m.migratedType(mode, typeName)
+		newAddress := migrateAddress(address, m)
+		r["type"] = newType
+		r["address"] = newAddress
+
+		attributesMigrated := false
+		if attrs, ok := r["values"].(map[string]interface{}); ok && mode == "managed" {
+			version, upgraded, err := m.upgradeAttributes(newType, r["schema_version"], attrs)
+			if err != nil {
+				return fmt.Errorf(" Error upgrading attributes of %s: %v", address, err)
+			}
+			if upgraded {
+				r["schema_version"] = version
+				attributesMigrated = true
+			}
+		}
+		if deps, ok := r["depends_on"].([]interface{}); ok {
+			r["depends_on"] = m.migrateAddresses(deps)
+		}
+		m.recordChange(address, newAddress, attributesMigrated)
+	}
+
+	children, _ := module["child_modules"].([]interface{})
+	for _, c := range children {
+		if child, ok := c.(map[string]interface{}); ok {
+			if err := m.migrateShowModule(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// upgradeAttributes runs state upgraders of resource type, the same ones Terraform runs on refresh, on attributes
+// written with older schema version. Attributes are given either as JSON object of state format version 4 or as
+// flatmap of state converted from earlier versions. It returns schema version of upgraded attributes.
+func (m *stateMigrator) upgradeAttributes(typeName string, schemaVersion interface{}, attrs map[string]interface{}) (int, bool, error) {
+	r, ok := m.resources[typeName]
+	if !ok {
+		return 0, false, nil
+	}
+	version := 0
+	if n, ok := schemaVersion.(json.Number); ok {
+		v, err := n.Int64()
+		if err != nil {
+			return 0, false, fmt.Errorf(" Invalid schema version %v", schemaVersion)
+		}
+		version = int(v)
+	}
+	if version >= r.SchemaVersion {
+		return version, false, nil
+	}
+
+	state := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		state[k] = v
+	}
+	for _, upgrader := range r.StateUpgraders {
+		if upgrader.Version < version {
+			continue
+		}
+		var err error
+		if state, err = upgrader.Upgrade(state, nil); err != nil {
+			return 0, false, err
+		}
+	}
+	for k := range attrs {
+		delete(attrs, k)
+	}
+	for k, v := range state {
+		attrs[k] = v
+	}
+	return r.SchemaVersion, true, nil
+}
+
+func (m *stateMigrator) recordChange(oldAddress, newAddress string, attributesMigrated bool) {
+	switch {
+	case oldAddress != newAddress:
+		m.changes = append(m.changes, oldAddress+" -> "+newAddress)
+	case attributesMigrated:
+		m.changes = append(m.changes, newAddress)
+	}
+}
+
+func (m *stateMigrator) migrateAddresses(addresses []interface{}) []interface{} {
+	migrated := make([]interface{}, len(addresses))
+	for i, v := range addresses {
+		if address, ok := v.(string); ok {
+			migrated[i] = migrateAddress(address, m)
+		} else {
+			migrated[i] = v
+		}
+	}
+	return migrated
+}
+
+// migrateAddress replaces deprecated type in resource address such as module.a["x"].data.centrifyvault_role.r[0]
+func migrateAddress(address string, m *stateMigrator) string {
+	parts := splitAddress(address)
+	for i := 0; i < len(parts); i++ {
+		switch parts[i] {
+		case "module":
+			// Skip module name
+			i++
+		case "data":
+			if i+1 < len(parts) {
+				parts[i+1] = m.migratedType("data", parts[i+1])
+			}
+			return strings.Join(parts, ".")
+		default:
+			parts[i] = m.migratedType("managed", parts[i])
+			return strings.Join(parts, ".")
+		}
+	}
+	return address
+}
+
+// splitAddress splits resource address on dots that aren't part of an instance key
+func splitAddress(address string) []string {
+	var parts []string
+	start, depth, quoted := 0, 0, false
+	for i := 0; i < len(address); i++ {
+		switch c := address[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			parts = append(parts, address[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, address[start:])
+}
+
+func resourceAddress(module, mode, typeName, name string) string {
+	address := typeName + "." + name
+	if mode == "data" {
+		address = "data." + address
+	}
+	if module != "" {
+		address = module + "." + address
+	}
+	return address
+}
+
+// migrateGroupMappingsAttributes converts mapping block set of attribute_value and group_name, used before 0.2.2,
+// to map of attribute value to group name
+func migrateGroupMappingsAttributes(attrs map[string]interface{}) bool {
+	// State format version 4
+	if list, ok := attrs["mapping"].([]interface{}); ok {
+		mappings := make(map[string]interface{})
+		for _, v := range list {
+			if block, ok := v.(map[string]interface{}); ok {
+				if key, ok := block["attribute_value"].(string); ok {
+					mappings[key] = block["group_name"]
+				}
+			}
+		}
+		attrs["mapping"] = mappings
+		return true
+	}
+
+	// Flatmap such as mapping.# = 1, mapping.1234.attribute_value = x and mapping.1234.group_name = y
+	if _, ok := attrs["mapping.#"]; !ok {
+		return false
+	}
+	var hashes []string
+	for k := range attrs {
+		if strings.HasPrefix(k, "mapping.") && strings.HasSuffix(k, ".attribute_value") {
+			hashes = append(hashes, strings.TrimSuffix(strings.TrimPrefix(k, "mapping."), ".attribute_value"))
+		}
+	}
+	mappings := make(map[string]interface{})
+	for _, h := range hashes {
+		if key, ok := attrs["mapping."+h+".attribute_value"].(string); ok {
+			mappings["mapping."+key] = attrs["mapping."+h+".group_name"]
+		}
+	}
+	for k := range attrs {
+		if strings.HasPrefix(k, "mapping.") {
+			delete(attrs, k)
+		}
+	}
+	for k, v := range mappings {
+		attrs[k] = v
+	}
+	attrs["mapping.%"] = fmt.Sprintf("%d", len(mappings))
+	return true
+}
@@ -0,0 +1,272 @@
+package centrify
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const testStateV4 = `{
+  "version": 4,
+  "terraform_version": "0.14.11",
+  "serial": 7,
+  "lineage": "5c2d1c0e-8f7e-4f7a-9d1e-0a3b0c6d1f00",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "data",
+      "type": "centrifyvault_vaultsystem",
+      "name": "dc",
+      "provider": "provider[\"registry.terraform.io/marcozj/centrify\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "sys-1", "name": "DC01"}}]
+    },
+    {
+      "module": "module.vault",
+      "mode": "managed",
+      "type": "centrifyvault_vaultaccount",
+      "name": "admin",
+      "provider": "provider[\"registry.terraform.io/marcozj/centrify\"]",
+      "instances": [{
+        "schema_version": 0,
+        "attributes": {"id": "acct-1", "name": "administrator", "host_id": "sys-1"},
+        "dependencies": ["data.centrifyvault_vaultsystem.dc", "module.vault.centrifyvault_role.admins"]
+      }]
+    },
+    {
+      "mode": "managed",
+      "type": "centrifyvault_globalgroupmappings",
+      "name": "mappings",
+      "provider": "provider[\"registry.terraform.io/marcozj/centrify\"]",
+      "instances": [{
+        "schema_version": 0,
+        "attributes": {
+          "id": "centrifyvault_global_group_mappings",
+          "bulkupdate": true,
+          "mapping": [{"attribute_value": "Idp Group 1", "group_name": "PAS Users"}]
+        }
+      }]
+    },
+    {
+      "mode": "managed",
+      "type": "centrify_domainreconciliation",
+      "name": "config",
+      "provider": "provider[\"registry.terraform.io/marcozj/centrify\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "dom-1-configuration", "domain_id": "dom-1"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "centrify_role",
+      "name": "current",
+      "provider": "provider[\"registry.terraform.io/marcozj/centrify\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "role-1", "name": "Current"}}]
+    }
+  ]
+}`
+
+func TestMigrateState(t *testing.T) {
+	migration, err := MigrateState([]byte(testStateV4))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var state struct {
+		Serial    int `json:"serial"`
+		Resources []struct {
+			Module    string `json:"module"`
+			Type      string `json:"type"`
+			Instances []struct {
+				Attributes   map[string]interface{} `json:"attributes"`
+				Dependencies []string               `json:"dependencies"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(migration.State, &state); err != nil {
+		t.Fatalf("Migrated state is not valid JSON: %v", err)
+	}
+	if state.Serial != 8 {
+		t.Errorf("Expected serial to be incremented to 8, got %d", state.Serial)
+	}
+
+	var types []string
+	for _, r := range state.Resources {
+		types = append(types, r.Type)
+	}
+	want := []string{"centrify_system", "centrify_account", "centrify_globalgroupmappings", "centrify_domainconfiguration", "centrify_role"}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Expected types %v, got %v", want, types)
+	}
+
+	deps := state.Resources[1].Instances[0].Dependencies
+	wantDeps := []string{"data.centrify_system.dc", "module.vault.centrify_role.admins"}
+	if !reflect.DeepEqual(deps, wantDeps) {
+		t.Errorf("Expected dependencies %v, got %v", wantDeps, deps)
+	}
+
+	mapping := state.Resources[2].Instances[0].Attributes["mapping"]
+	wantMapping := map[string]interface{}{"Idp Group 1": "PAS Users"}
+	if !reflect.DeepEqual(mapping, wantMapping) {
+		t.Errorf("Expected mapping %v, got %v", wantMapping, mapping)
+	}
+
+	if len(migration.Changes) != 4 || migration.Changes[1] != "module.vault.centrifyvault_vaultaccount.admin -> module.vault.centrify_account.admin" {
+		t.Errorf("Unexpected changes: %v", migration.Changes)
+	}
+}
+
+func TestMigrateState_upgraders(t *testing.T) {
+	state := `{
+  "version": 4,
+  "serial": 1,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "centrifyvault_vaultdomain",
+      "name": "example",
+      "instances": [{"schema_version": 0, "attributes": {"id": "dom-1", "name": "example.com", "enable_zone_role_cleanup": true, "zone_role_cleanup_interval": 6}}]
+    },
+    {
+      "mode": "managed",
+      "type": "centrify_domain",
+      "name": "flat",
+      "instances": [{"schema_version": 0, "attributes_flat": {"id": "dom-2", "name": "flat.com", "enable_zone_role_cleanup": "true"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "centrify_domainconfiguration",
+      "name": "config",
+      "instances": [{"schema_version": 0, "attributes": {"id": "dom-1-reconciliation", "domain_id": ""}}]
+    }
+  ]
+}`
+	migration, err := MigrateState([]byte(state))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var migrated struct {
+		Resources []struct {
+			Type      string `json:"type"`
+			Instances []struct {
+				SchemaVersion  int                    `json:"schema_version"`
+				Attributes     map[string]interface{} `json:"attributes"`
+				AttributesFlat map[string]interface{} `json:"attributes_flat"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(migration.State, &migrated); err != nil {
+		t.Fatalf("Migrated state is not valid JSON: %v", err)
+	}
+
+	// Attributes must be the same as upgraded by Terraform on refresh
+	for i, raw := range []map[string]interface{}{
+		{"id": "dom-1", "name": "example.com", "enable_zone_role_cleanup": true, "zone_role_cleanup_interval": float64(6)},
+		{"id": "dom-2", "name": "flat.com", "enable_zone_role_cleanup": "true"},
+		{"id": "dom-1-reconciliation", "domain_id": ""},
+	} {
+		upgrade := resourceDomainStateUpgradeV0
+		if i == 2 {
+			upgrade = resourceDomainConfigurationStateUpgradeV0
+		}
+		want, _ := upgrade(raw, nil)
+		instance := migrated.Resources[i].Instances[0]
+		got := instance.Attributes
+		if i == 1 {
+			got = instance.AttributesFlat
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected attributes of %s to be %v, got %v", migrated.Resources[i].Type, want, got)
+		}
+		if instance.SchemaVersion != 1 {
+			t.Errorf("Expected schema version of %s to be 1, got %d", migrated.Resources[i].Type, instance.SchemaVersion)
+		}
+	}
+	if len(migration.Changes) != 3 {
+		t.Errorf("Unexpected changes: %v", migration.Changes)
+	}
+}
+
+func TestMigrateState_conflict(t *testing.T) {
+	state := strings.Replace(testStateV4, `"name": "current"`, `"name": "admin", "module": "module.vault"`, 1)
+	state = strings.Replace(state, `"type": "centrify_role"`, `"type": "centrify_account"`, 1)
+	if _, err := MigrateState([]byte(state)); err == nil || !strings.Contains(err.Error(), "already contains") {
+		t.Errorf("Expected conflicting address error, got %v", err)
+	}
+}
+
+func TestMigrateState_unsupportedVersion(t *testing.T) {
+	if _, err := MigrateState([]byte(`{"version": 3, "serial": 1, "modules": []}`)); err == nil {
+		t.Error("Expected unsupported state version error")
+	}
+}
+
+func TestMigrateState_showOutput(t *testing.T) {
+	show := `{
+  "format_version": "0.1",
+  "terraform_version": "0.14.11",
+  "values": {
+    "root_module": {
+      "child_modules": [{
+        "address": "module.vault[\"a.b\"]",
+        "resources": [{
+          "address": "module.vault[\"a.b\"].centrifyvault_manualset.set[0]",
+          "mode": "managed",
+          "type": "centrifyvault_manualset",
+          "name": "set",
+          "index": 0,
+          "provider_name": "registry.terraform.io/marcozj/centrify",
+          "schema_version": 0,
+          "values": {"id": "set-1", "name": "Set"},
+          "depends_on": ["data.centrifyvault_role.admins"]
+        }]
+      }]
+    }
+  }
+}`
+	migration, err := MigrateState([]byte(show))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, s := range []string{
+		`"address": "module.vault[\"a.b\"].centrify_manualset.set[0]"`,
+		`"type": "centrify_manualset"`,
+		`"data.centrify_role.admins"`,
+	} {
+		if !strings.Contains(string(migration.State), s) {
+			t.Errorf("Expected migrated output to contain %s, got %s", s, migration.State)
+		}
+	}
+}
+
+func TestTypeMigrations(t *testing.T) {
+	provider := Provider()
+	resources := make(map[string]bool)
+	for name := range provider.ResourcesMap {
+		resources[name] = true
+	}
+	migrations := typeMigrations(resources)
+	for name := range provider.ResourcesMap {
+		if strings.HasPrefix(name, deprecatedTypePrefix) {
+			to, ok := migrations[name]
+			if !ok {
+				t.Errorf("No migration for deprecated resource %s", name)
+				continue
+			}
+			if !reflect.DeepEqual(schemaKeys(provider.ResourcesMap[name].Schema), schemaKeys(provider.ResourcesMap[to].Schema)) {
+				t.Errorf("Resource %s is migrated to %s that has different schema", name, to)
+			}
+		}
+	}
+}
+
+func schemaKeys(s map[string]*schema.Schema) []string {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,6 +2,7 @@ package centrify
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -12,7 +13,7 @@ var logPath string
 
 // Provider returns a schema.Provider for Centrify Platform.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:        schema.TypeString,
@@ -20,54 +21,86 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_URL", "VAULT_URL"}, ""),
 				Description: "Centrify Platform URL",
 			},
+			"auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Authentication method. If this isn't provided, appid, scope, username, password, token and use_dmc arguments are used",
+				Elem:        authSchema(),
+			},
 			"appid": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_APPID", "VAULT_APPID"}, ""),
 				Description: "Application ID",
 			},
 			"scope": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_SCOPE", "VAULT_SCOPE"}, ""),
 				Description: "OAuth2 scope",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_USERNAME", "VAULT_USERNAME"}, ""),
 				Description: "Username",
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_PASSWORD", "VAULT_PASSWORD"}, ""),
 				Description: "Password",
 			},
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_TOKEN", "VAULT_TOKEN"}, ""),
 				Description: "OAuth or DMC token",
 			},
 			"use_dmc": {
 				Type:        schema.TypeBool,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_USEDMC", "VAULT_USEDMC"}, false),
 				Description: "Whether to use DMC",
 			},
 			"logpath": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_LOGPATH", "VAULT_LOGPATH"}, ""),
 				Description: "Path of log file",
 			},
 			"skip_cert_verify": {
 				Type:        schema.TypeBool,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_SKIPCERTVERIFY", "VAULT_SKIPCERTVERIFY"}, false),
 				Description: "Whether to skip certification verification",
 			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_CACERTFILE", "VAULT_CACERTFILE"}, ""),
+				Description: "Path of PEM encoded CA certificate bundle used to verify tenant certificate",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_CACERTPEM", "VAULT_CACERTPEM"}, ""),
+				Description: "PEM encoded CA certificate bundle used to verify tenant certificate",
+			},
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_CLIENTCERT", "VAULT_CLIENTCERT"}, ""),
+				Description: "PEM encoded client certificate or path of it for mutual TLS",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_CLIENTKEY", "VAULT_CLIENTKEY"}, ""),
+				Description: "PEM encoded client private key or path of it for mutual TLS",
+			},
 			"log_level": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -81,6 +114,68 @@ func Provider() *schema.Provider {
 				}, false),
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_LOGLEVEL", "VAULT_LOGLEVEL"}, "Error"),
 			},
+			"log_redact_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional attribute names whose values are masked in logs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"audit_journal_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of file to which every API call that modifies tenant data is recorded as JSON line",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_AUDITJOURNALPATH", "VAULT_AUDITJOURNALPATH"}, nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_MAXRETRIES", "VAULT_MAXRETRIES"}, 3),
+				Description:  "Maximum number of retries for throttled or transient API failures",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_RETRYMINWAIT", "VAULT_RETRYMINWAIT"}, 1),
+				Description:  "Minimum time in seconds to wait before retrying a failed API call",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_RETRYMAXWAIT", "VAULT_RETRYMAXWAIT"}, 30),
+				Description:  "Maximum time in seconds to wait before retrying a failed API call",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_REQUESTSPERSECOND", "VAULT_REQUESTSPERSECOND"}, 0),
+				Description:  "Maximum number of API calls per second. 0 means unlimited",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_MAXCONCURRENTREQUESTS", "VAULT_MAXCONCURRENTREQUESTS"}, 0),
+				Description:  "Maximum number of API calls in flight. 0 means unlimited",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"visibility_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"CENTRIFY_VISIBILITYTIMEOUT", "VAULT_VISIBILITYTIMEOUT"}, 60),
+				Description:  "Maximum time in seconds to wait for newly created object to become readable. 0 disables waiting",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rollback_on_failed_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CENTRIFY_ROLLBACKONFAILEDCREATE", "VAULT_ROLLBACKONFAILEDCREATE"}, false),
+				Description: "Delete partially created object when a later step of its creation fails",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"centrifyvault_user":                  dataSourceUser_deprecated(),
@@ -109,7 +204,9 @@ func Provider() *schema.Provider {
 			"centrifyvault_webapp_generic":        dataSourceGenericWebApp_deprecated(),
 			// Change centrifyvault_* centrify_*
 			"centrify_user":                  dataSourceUser(),
+			"centrify_users":                 dataSourceUsers(),
 			"centrify_role":                  dataSourceRole(),
+			"centrify_roles":                 dataSourceRoles(),
 			"centrify_policy":                dataSourcePolicy(),
 			"centrify_manualset":             dataSourceManualSet(),
 			"centrify_passwordprofile":       dataSourcePasswordProfile(),
@@ -117,14 +214,17 @@ func Provider() *schema.Provider {
 			"centrify_connector":             dataSourceConnector(),
 			"centrify_domain":                dataSourceDomain(),
 			"centrify_system":                dataSourceSystem(),
+			"centrify_systems":               dataSourceSystems(),
 			"centrify_database":              dataSourceDatabase(),
 			"centrify_account":               dataSourceAccount(),
+			"centrify_accounts":              dataSourceAccounts(),
 			"centrify_secret":                dataSourceSecret(),
 			"centrify_secretfolder":          dataSourceSecretFolder(),
 			"centrify_sshkey":                dataSourceSSHKey(),
 			"centrify_desktopapp":            dataSourceDesktopApp(),
 			"centrify_directoryservice":      dataSourceDirectoryService(),
 			"centrify_directoryobject":       dataSourceDirectoryObject(),
+			"centrify_directoryobjects":      dataSourceDirectoryObjects(),
 			"centrify_multiplexedaccount":    dataSourceMultiplexedAccount(),
 			"centrify_service":               dataSourceService(),
 			"centrify_cloudprovider":         dataSourceCloudProvider(),
@@ -133,6 +233,7 @@ func Provider() *schema.Provider {
 			"centrify_webapp_oidc":           dataSourceOidcWebApp(),
 			"centrify_webapp_generic":        dataSourceGenericWebApp(),
 			"centrify_federatedgroup":        dataSourceFederatedGroup(),
+			"centrify_query":                 dataSourceQuery(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"centrifyvault_user":                      resourceUser_deprecated(),
@@ -180,6 +281,7 @@ func Provider() *schema.Provider {
 			"centrify_system":                resourceSystem(),
 			"centrify_database":              resourceDatabase(),
 			"centrify_account":               resourceAccount(),
+			"centrify_account_checkout":      resourceAccountCheckout(),
 			"centrify_secret":                resourceSecret(),
 			"centrify_secretfolder":          resourceSecretFolder(),
 			"centrify_sshkey":                resourceSSHKey(),
@@ -195,52 +297,263 @@ func Provider() *schema.Provider {
 			"centrify_webapp_generic":        resourceGenericWebApp(),
 			"centrify_federatedgroup":        resourceFederatedGroup(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	for name, r := range provider.ResourcesMap {
+		wrapResourceOperations(name, r)
+	}
+	for name, r := range provider.DataSourcesMap {
+		wrapResourceOperations("data."+name, r)
+	}
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider)
+	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, provider *schema.Provider) (interface{}, error) {
 	config := Config{
-		URL:            d.Get("url").(string),
-		AppID:          d.Get("appid").(string),
-		Scope:          d.Get("scope").(string),
-		Username:       d.Get("username").(string),
-		Password:       d.Get("password").(string),
-		Token:          d.Get("token").(string),
-		UseDMC:         d.Get("use_dmc").(bool),
-		LogPath:        d.Get("logpath").(string),
-		SkipCertVerify: d.Get("skip_cert_verify").(bool),
-		LogLevel:       d.Get("log_level").(string),
+		URL:                    d.Get("url").(string),
+		AppID:                  d.Get("appid").(string),
+		Scope:                  d.Get("scope").(string),
+		Username:               d.Get("username").(string),
+		Password:               d.Get("password").(string),
+		Token:                  d.Get("token").(string),
+		UseDMC:                 d.Get("use_dmc").(bool),
+		LogPath:                d.Get("logpath").(string),
+		SkipCertVerify:         d.Get("skip_cert_verify").(bool),
+		CACertFile:             d.Get("ca_cert_file").(string),
+		CACertPEM:              d.Get("ca_cert_pem").(string),
+		ClientCert:             d.Get("client_cert").(string),
+		ClientKey:              d.Get("client_key").(string),
+		LogLevel:               d.Get("log_level").(string),
+		MaxRetries:             d.Get("max_retries").(int),
+		RetryMinWait:           time.Duration(d.Get("retry_min_wait").(int)) * time.Second,
+		RetryMaxWait:           time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		RequestsPerSecond:      d.Get("requests_per_second").(float64),
+		MaxConcurrent:          d.Get("max_concurrent_requests").(int),
+		VisibilityTimeout:      time.Duration(d.Get("visibility_timeout").(int)) * time.Second,
+		RollbackOnFailedCreate: d.Get("rollback_on_failed_create").(bool),
+		AuditJournalPath:       d.Get("audit_journal_path").(string),
 	}
 	switch config.LogLevel {
 	case "fatal":
-		logger.SetLevel(logger.LevelFatal)
+		setLogLevel(logger.LevelFatal)
 	case "error":
-		logger.SetLevel(logger.LevelError)
+		setLogLevel(logger.LevelError)
 	case "info":
-		logger.SetLevel(logger.LevelInfo)
+		setLogLevel(logger.LevelInfo)
 	case "debug":
-		logger.SetLevel(logger.LevelDebug)
+		setLogLevel(logger.LevelDebug)
+	}
+	if config.LogPath == "" {
+		// Logs are written to stderr and filtered by Terraform so follow its log level
+		if level, ok := logLevelFromEnv(); ok {
+			setLogLevel(level)
+		}
 	}
 
 	logPath = config.LogPath
 
+	// Mask sensitive attributes and deny-listed fields before anything is logged
+	redactFields := append(sensitiveFieldNames(provider), defaultRedactedFields...)
+	redactFields = append(redactFields, flattenTypeListToSlice(d.Get("log_redact_fields"))...)
+	config.redactor = newRedactor(redactFields)
+	if err := setupLogOutput(config.LogPath, config.redactor); err != nil {
+		return nil, err
+	}
 	if config.LogPath != "" {
-		logger.SetLogPath(config.LogPath)
 		logger.EnableErrorStackTrace()
 	}
 
+	if v, ok := d.GetOk("auth"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		expandProviderAuth(v.([]interface{})[0].(map[string]interface{}), &config)
+	}
+
 	logger.Infof("Starting provider configuration...")
+	logger.Infof("Using %s authentication", config.authMethod())
 	if err := config.Valid(); err != nil {
 		return nil, err
 	}
 
-	restClient, err := config.getClient()
+	meta, err := config.getClient()
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate to Centrify Platform: %v", err)
 	}
 	logger.Infof("Connected to Centrify Platform %s", config.URL)
 
-	return restClient, nil
+	return meta, nil
+}
+
+func authSchema() *schema.Resource {
+	methods := []string{
+		"auth.0." + authMethodClientCredentials,
+		"auth.0." + authMethodOauthToken,
+		"auth.0." + authMethodDMC,
+		"auth.0." + authMethodTokenFile,
+		"auth.0." + authMethodCredentialProcess,
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			authMethodClientCredentials: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: methods,
+				Description:  "OAuth2 client credentials authentication",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"appid": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Application ID",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"scope": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "OAuth2 scope",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"client_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "OAuth2 client ID",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"client_secret": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							Description:  "OAuth2 client secret",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			authMethodOauthToken: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: methods,
+				Description:  "OAuth2 token authentication",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							Description:  "OAuth2 token",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+			},
+			authMethodDMC: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: methods,
+				Description:  "DMC authentication",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scope": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "DMC scope",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+			},
+			authMethodTokenFile: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: methods,
+				Description:  "Authentication with OAuth2 or DMC token read from file",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Path of token file",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+			},
+			authMethodCredentialProcess: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: methods,
+				Description:  "Authentication with token or OAuth2 client credentials printed by external command",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Command and its arguments",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+						"appid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Application ID. Required if command prints client credentials",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "OAuth2 scope. Required if command prints client credentials",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandProviderAuth sets authentication method and credentials from auth block.
+// Flat authentication arguments are ignored when auth block is used.
+func expandProviderAuth(auth map[string]interface{}, config *Config) {
+	config.AppID = ""
+	config.Scope = ""
+	config.Username = ""
+	config.Password = ""
+	config.Token = ""
+	config.UseDMC = false
+
+	for _, method := range []string{authMethodClientCredentials, authMethodOauthToken, authMethodDMC, authMethodTokenFile, authMethodCredentialProcess} {
+		v, ok := auth[method].([]interface{})
+		if !ok || len(v) == 0 || v[0] == nil {
+			continue
+		}
+		m := v[0].(map[string]interface{})
+		config.AuthMethod = method
+		switch method {
+		case authMethodClientCredentials:
+			config.AppID = m["appid"].(string)
+			config.Scope = m["scope"].(string)
+			config.Username = m["client_id"].(string)
+			config.Password = m["client_secret"].(string)
+		case authMethodOauthToken:
+			config.Token = m["token"].(string)
+		case authMethodDMC:
+			config.Scope = m["scope"].(string)
+			config.UseDMC = true
+		case authMethodTokenFile:
+			config.TokenFile = m["path"].(string)
+		case authMethodCredentialProcess:
+			config.CredentialProcess = flattenTypeListToSlice(m["command"])
+			config.AppID = m["appid"].(string)
+			config.Scope = m["scope"].(string)
+		}
+		return
+	}
 }
@@ -1,11 +1,16 @@
 package centrify
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -17,11 +22,129 @@ func init() {
 		"centrify": testAccProvider,
 	}
 }
+
+// TestMain points the provider at an in-process mock tenant unless CENTRIFY_URL
+// is set, so that acceptance tests can run without a live tenant
+func TestMain(m *testing.M) {
+	if os.Getenv("CENTRIFY_URL") == "" {
+		server := mocktenant.NewServer()
+		setMockTenantEnv(server)
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+	os.Exit(m.Run())
+}
+
+func setMockTenantEnv(server *mocktenant.Server) {
+	os.Setenv("CENTRIFY_URL", server.URL)
+	os.Setenv("CENTRIFY_APPID", server.AppID)
+	os.Setenv("CENTRIFY_SCOPE", server.Scope)
+	os.Setenv("CENTRIFY_USERNAME", server.ClientID)
+	os.Setenv("CENTRIFY_PASSWORD", server.ClientSecret)
+	os.Setenv("CENTRIFY_SKIPCERTVERIFY", "true")
+}
+
+// testMockMeta starts a mock tenant and returns provider meta authenticated to it
+// with the tenant's OAuth token. Caller must close the server
+func testMockMeta(t *testing.T) (*mocktenant.Server, *providerMeta) {
+	server := mocktenant.NewServer()
+	return server, testMockClient(t, testMockTokenConfig(server))
+}
+
+func testMockTokenConfig(server *mocktenant.Server) Config {
+	return Config{URL: server.URL, AuthMethod: authMethodOauthToken, Token: server.Token, SkipCertVerify: true}
+}
+
+func testMockClient(t *testing.T, config Config) *providerMeta {
+	meta, err := config.getClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return meta
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProvider_authValidation(t *testing.T) {
+	cases := map[string]struct {
+		auth map[string]interface{}
+		err  string
+	}{
+		"no method": {
+			auth: map[string]interface{}{},
+			err:  "one of",
+		},
+		"two methods": {
+			auth: map[string]interface{}{
+				"oauth_token": []interface{}{map[string]interface{}{"token": "abc"}},
+				"dmc":         []interface{}{map[string]interface{}{"scope": "all"}},
+			},
+			err: "only one of",
+		},
+		"missing client secret": {
+			auth: map[string]interface{}{
+				"oauth_client_credentials": []interface{}{map[string]interface{}{"appid": "app", "scope": "all", "client_id": "admin"}},
+			},
+			err: "client_secret",
+		},
+	}
+
+	for name, tc := range cases {
+		raw := map[string]interface{}{
+			"url":  "https://tenant.example.com",
+			"auth": []interface{}{tc.auth},
+		}
+		_, errs := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+		var found bool
+		for _, err := range errs {
+			if strings.Contains(err.Error(), tc.err) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.err, errs)
+		}
+	}
+}
+
+func TestAccProvider_authBlock(t *testing.T) {
+	rName := acctest.RandomWithPrefix("test-user@")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderAuthBlockConfig(rName),
+				Check:  resource.TestCheckResourceAttr("centrify_user.testuser", "username", rName),
+			},
+		},
+	})
+}
+
+func testAccProviderAuthBlockConfig(rName string) string {
+	return fmt.Sprintf(`provider "centrify" {
+		auth {
+			oauth_client_credentials {
+				appid = %[2]q
+				scope = %[3]q
+				client_id = %[4]q
+				client_secret = %[5]q
+			}
+		}
+	}
+
+	resource "centrify_user" "testuser" {
+		username = %[1]q
+		password = "TestUser@123"
+	}`, rName, os.Getenv("CENTRIFY_APPID"), os.Getenv("CENTRIFY_SCOPE"), os.Getenv("CENTRIFY_USERNAME"), os.Getenv("CENTRIFY_PASSWORD"))
+}
+
 func testAccPreCheck(t *testing.T) {
 
 	if v := os.Getenv("CENTRIFY_URL"); v == "" {
@@ -0,0 +1,248 @@
+package centrify
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// redactedValue replaces sensitive values in logs
+const redactedValue = "******"
+
+// defaultRedactedFields are always masked in logs regardless of schema, as API payloads and
+// SDK objects use their own names for credentials
+var defaultRedactedFields = []string{
+	"password",
+	"confirm_password",
+	"secret",
+	"client_secret",
+	"secret_text",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"private_key",
+	"passphrase",
+	"secret_access_key",
+}
+
+// genericFieldNames are sensitive in some schema but too common to be masked everywhere in logs
+var genericFieldNames = map[string]bool{
+	"value": true,
+}
+
+// redactor masks values of sensitive fields in log messages
+type redactor struct {
+	fields map[string]bool
+}
+
+func newRedactor(fields []string) *redactor {
+	r := &redactor{fields: make(map[string]bool)}
+	for _, f := range fields {
+		r.fields[normalizeFieldName(f)] = true
+	}
+	return r
+}
+
+// normalizeFieldName makes schema attribute names such as secret_text match API names such as SecretText
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// Redact returns message with values of sensitive fields replaced. Keys are recognized as printed in JSON
+// ("key": value) or by fmt for maps and structs (key:value). Quoted strings are never searched for keys.
+func (r *redactor) Redact(msg string) string {
+	var b strings.Builder
+	pos := 0
+	for i := 0; i < len(msg); {
+		var key string
+		var valueFrom int
+		var quotedKey bool
+		switch {
+		case msg[i] == '"' && stringStart(msg, i):
+			end, ok := quotedEnd(msg, i)
+			if !ok {
+				i++
+				continue
+			}
+			colon := skipSpaces(msg, end)
+			if colon >= len(msg) || msg[colon] != ':' {
+				// String value or text, skip it as a whole
+				i = end
+				continue
+			}
+			key, valueFrom, quotedKey = msg[i+1:end-1], colon+1, true
+		case isKeyChar(msg[i]) && keyStart(msg, i):
+			end := i
+			for end < len(msg) && isKeyChar(msg[end]) {
+				end++
+			}
+			if end >= len(msg) || msg[end] != ':' {
+				i = end
+				continue
+			}
+			key, valueFrom = msg[i:end], end+1
+		default:
+			i++
+			continue
+		}
+
+		if !r.fields[normalizeFieldName(key)] {
+			i = valueFrom
+			continue
+		}
+		start, end := valueSpan(msg, valueFrom, quotedKey)
+		if start < end {
+			b.WriteString(msg[pos:start])
+			b.WriteString(redactedValue)
+			pos = end
+		}
+		i = end
+		if i < valueFrom {
+			i = valueFrom
+		}
+	}
+	b.WriteString(msg[pos:])
+
+	return b.String()
+}
+
+// valueSpan returns position of value that starts after a key ending at from. Value of quoted JSON key
+// ends at a separator. Value printed by fmt ends at a closing bracket of enclosing map or struct, or at
+// whitespace followed by the next key, so it may contain colons.
+func valueSpan(msg string, from int, quotedKey bool) (int, int) {
+	start := skipSpaces(msg, from)
+	if start > from && keyFollows(msg, start) {
+		// Empty value
+		return start, start
+	}
+	if start < len(msg) && msg[start] == '"' {
+		if end, ok := quotedEnd(msg, start); ok {
+			return start + 1, end - 1
+		}
+	}
+
+	end := start
+	depth := 0
+loop:
+	for ; end < len(msg); end++ {
+		switch c := msg[end]; c {
+		case '\n':
+			break loop
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case ',':
+			if quotedKey && depth == 0 {
+				break loop
+			}
+		case ' ', '\t':
+			if quotedKey || depth == 0 && keyFollows(msg, skipSpaces(msg, end)) {
+				break loop
+			}
+		}
+	}
+	for end > start && (msg[end-1] == ' ' || msg[end-1] == '\t') {
+		end--
+	}
+	return start, end
+}
+
+// quotedEnd returns position after closing quote of string starting at i
+func quotedEnd(msg string, i int) (int, bool) {
+	for end := i + 1; end < len(msg); end++ {
+		switch msg[end] {
+		case '\\':
+			end++
+		case '"':
+			return end + 1, true
+		}
+	}
+	return len(msg), false
+}
+
+// stringStart tells whether quote at i opens a JSON key or string rather than being part of unquoted text
+func stringStart(msg string, i int) bool {
+	i--
+	for i >= 0 && (msg[i] == ' ' || msg[i] == '\t') {
+		i--
+	}
+	return i < 0 || strings.IndexByte("{[,:", msg[i]) >= 0
+}
+
+// keyStart tells whether identifier at i can be a key printed by fmt, which follows a bracket or whitespace
+func keyStart(msg string, i int) bool {
+	return i == 0 || strings.IndexByte(" \t\n[{(,", msg[i-1]) >= 0
+}
+
+// keyFollows tells whether an identifier followed by colon starts at i
+func keyFollows(msg string, i int) bool {
+	end := i
+	for end < len(msg) && isKeyChar(msg[end]) {
+		end++
+	}
+	return end > i && end < len(msg) && msg[end] == ':'
+}
+
+func isKeyChar(c byte) bool {
+	return c == '_' || c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func skipSpaces(msg string, i int) int {
+	for i < len(msg) && (msg[i] == ' ' || msg[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// sensitiveFieldNames returns names of all attributes marked Sensitive in provider, resource and data source schemas
+func sensitiveFieldNames(p *schema.Provider) []string {
+	names := make(map[string]bool)
+	collectSensitiveFields(p.Schema, names)
+	for _, r := range p.ResourcesMap {
+		collectSensitiveFields(r.Schema, names)
+	}
+	for _, r := range p.DataSourcesMap {
+		collectSensitiveFields(r.Schema, names)
+	}
+
+	var result []string
+	for name := range names {
+		result = append(result, name)
+	}
+	return result
+}
+
+func collectSensitiveFields(s map[string]*schema.Schema, names map[string]bool) {
+	for name, v := range s {
+		if v.Sensitive && !genericFieldNames[name] {
+			names[name] = true
+		}
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			collectSensitiveFields(elem.Schema, names)
+		}
+	}
+}
+
+// redactWriter - io.Writer that masks sensitive values before writing log output
+type redactWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	redactor *redactor
+}
+
+func (w *redactWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.w.Write([]byte(w.redactor.Redact(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
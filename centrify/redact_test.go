@@ -0,0 +1,97 @@
+package centrify
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	logger "github.com/marcozj/golang-sdk/logging"
+)
+
+func TestRedactor(t *testing.T) {
+	r := newRedactor([]string{"secret_text", "proxyuser_password", "password"})
+	cases := map[string]string{
+		"map[secret_name:test secret_text:s3cr3t type:Text]":      "map[secret_name:test secret_text:****** type:Text]",
+		"&{Name:admin ProxyUserPassword:pa ss Description:x}":     "&{Name:admin ProxyUserPassword:****** Description:x}",
+		`{"SecretText":"s3\"cr3t","SecretName":"test"}`:           `{"SecretText":"******","SecretName":"test"}`,
+		"map[password: username:admin]":                           "map[password: username:admin]",
+		`Generated Map: map[name:x password:abc] at 10:51:59`:     `Generated Map: map[name:x password:******] at 10:51:59`,
+		"map[challenge_rule:[map[rule:[]]] secret_text:s3cr3t]":   "map[challenge_rule:[map[rule:[]]] secret_text:******]",
+		"nothing sensitive here: https://tenant.example.com/path": "nothing sensitive here: https://tenant.example.com/path",
+		"map[name:foo secret_text:abc:def]":                       "map[name:foo secret_text:******]",
+		"map[secret_text:abc:def name:foo]":                       "map[secret_text:****** name:foo]",
+		"map[password:http://x name:y]":                           "map[password:****** name:y]",
+		"&{SecretText:abc:def Name:foo}":                          "&{SecretText:****** Name:foo}",
+		"map[password:a [b c:d] name:y]":                          "map[password:****** name:y]",
+		`&{Name:admin Password:"abc:def" Type:x}`:                 `&{Name:admin Password:"******" Type:x}`,
+		`{"Description": "password: hunter2"}`:                    `{"Description": "password: hunter2"}`,
+		`{"Description": "x", "Password": "p:w", "Name": "y"}`:    `{"Description": "x", "Password": "******", "Name": "y"}`,
+		`{"Password":1234,"Name":"y"}`:                            `{"Password":******,"Name":"y"}`,
+		`map[description:5" screen password:abc]`:                 `map[description:5" screen password:******]`,
+		"map[value:42]": "map[value:42]",
+	}
+	for in, expected := range cases {
+		if got := r.Redact(in); got != expected {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, expected)
+		}
+	}
+}
+
+func TestSensitiveFieldNames(t *testing.T) {
+	names := sensitiveFieldNames(Provider())
+	for _, name := range names {
+		if name == "value" {
+			t.Errorf("Generic field %s must not be redacted", name)
+		}
+	}
+	for _, expected := range []string{"secret_text", "password", "client_key"} {
+		found := false
+		for _, name := range names {
+			if name == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected sensitive field %s", expected)
+		}
+	}
+}
+
+func TestSetupLogOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "centrify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	out := log.Writer()
+	defer func() {
+		setupLogOutput("", newRedactor(nil))
+		log.SetOutput(out)
+		setLogLevel(logger.LevelError)
+	}()
+
+	logPath := filepath.Join(dir, "provider.log")
+	if err := setupLogOutput(logPath, newRedactor(append(sensitiveFieldNames(Provider()), "custom_field"))); err != nil {
+		t.Fatal(err)
+	}
+	setLogLevel(logger.LevelDebug)
+	logger.Debugf("Generated Map for resourceSecretRead(): %+v", map[string]interface{}{
+		"secret_name":  "test",
+		"secret_text":  "s3cr3t",
+		"custom_field": "hidden",
+	})
+
+	content, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "s3cr3t") || strings.Contains(string(content), "hidden") {
+		t.Errorf("Sensitive value is written to log: %s", content)
+	}
+	if !strings.Contains(string(content), "secret_name:test") {
+		t.Errorf("Expected non-sensitive value in log: %s", content)
+	}
+}
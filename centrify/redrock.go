@@ -0,0 +1,133 @@
+package centrify
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// redrockPageSize is number of rows fetched by each call of Redrock query service
+const redrockPageSize = 1000
+
+// redrockQuote quotes value as string literal of Redrock query
+func redrockQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// redrockConditions are conditions of WHERE clause of Redrock query
+type redrockConditions []string
+
+// equals adds condition that column equals value, unless value is empty
+func (c *redrockConditions) equals(column, value string) {
+	if value != "" {
+		*c = append(*c, column+"="+redrockQuote(value))
+	}
+}
+
+// hasPrefix adds condition that column starts with prefix, ignoring case, unless prefix is empty. % and _ in prefix
+// are wildcards of LIKE, so rows still need to be checked for the exact prefix.
+func (c *redrockConditions) hasPrefix(column, prefix string) {
+	if prefix != "" {
+		*c = append(*c, column+" LIKE "+redrockQuote(prefix+"%"))
+	}
+}
+
+// where returns WHERE clause that matches all conditions, or empty string if there are none
+func (c redrockConditions) where() string {
+	if len(c) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c, " AND ")
+}
+
+// truncatedQueryError is returned when Redrock query returns fewer rows than it matches
+type truncatedQueryError struct {
+	rows      int
+	fullCount int
+}
+
+func (e *truncatedQueryError) Error() string {
+	return fmt.Sprintf("query returns only %d of %d rows", e.rows, e.fullCount)
+}
+
+// redrockQueryPages runs Redrock query with named parameters, referred to as @name in query, and fetches its rows page
+// by page. It fails once query returns more than maxRows rows, unless maxRows is 0 which fetches all rows.
+func redrockQueryPages(client *restapi.RestClient, query string, parameters map[string]string, maxRows int) ([]map[string]interface{}, error) {
+	var names []string
+	for k := range parameters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	params := []interface{}{}
+	for _, k := range names {
+		params = append(params, map[string]interface{}{"Name": k, "Value": parameters[k]})
+	}
+
+	var rows []map[string]interface{}
+	var previous []interface{}
+	for page := 1; ; page++ {
+		args := map[string]interface{}{
+			"PageNumber": page,
+			"PageSize":   redrockPageSize,
+			"Caching":    -1,
+			"Parameters": params,
+		}
+		resp, err := client.CallGenericMapAPI("/RedRock/query", map[string]interface{}{"Script": query, "Args": args})
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+		}
+
+		results, _ := resp.Result["Results"].([]interface{})
+		if len(results) > 0 && reflect.DeepEqual(results, previous) {
+			// Tenant ignores page number, fetching more pages would never end
+			return nil, fmt.Errorf("query returns the same rows for page %d as for page %d", page, page-1)
+		}
+		previous = results
+		for _, r := range results {
+			result, _ := r.(map[string]interface{})
+			if row, ok := result["Row"].(map[string]interface{}); ok {
+				rows = append(rows, row)
+			}
+		}
+		if maxRows > 0 && len(rows) > maxRows {
+			return nil, fmt.Errorf("query returns more than %d rows", maxRows)
+		}
+		fullCount, counted := resp.Result["FullCount"].(float64)
+		if counted && len(rows) >= int(fullCount) {
+			return rows, nil
+		}
+		if len(results) < redrockPageSize {
+			if counted {
+				// Tenant stopped returning rows before all matching rows were returned
+				return nil, &truncatedQueryError{rows: len(rows), fullCount: int(fullCount)}
+			}
+			return rows, nil
+		}
+	}
+}
+
+// setMembers returns IDs of members of a set
+func setMembers(client *restapi.RestClient, setID string) (map[string]bool, error) {
+	resp, err := client.CallSliceAPI("/Collection/GetMembers", map[string]interface{}{"ID": setID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s %s", resp.Message, resp.Exception)
+	}
+	members := make(map[string]bool)
+	for _, r := range resp.Result {
+		if member, ok := r.(map[string]interface{}); ok {
+			if key, ok := member["Key"].(string); ok {
+				members[key] = true
+			}
+		}
+	}
+	return members, nil
+}
@@ -2,11 +2,9 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -19,7 +17,7 @@ func resourceAuthenticationProfile_deprecated() *schema.Resource {
 		Delete: resourceAuthenticationProfileDelete,
 		Exists: resourceAuthenticationProfileExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceAuthenticationProfileImportID, "name"),
 		},
 
 		Schema:             getAuthenticationProfileSchema(),
@@ -35,7 +33,7 @@ func resourceAuthenticationProfile() *schema.Resource {
 		Delete: resourceAuthenticationProfileDelete,
 		Exists: resourceAuthenticationProfileExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceAuthenticationProfileImportID, "name"),
 		},
 
 		Schema: getAuthenticationProfileSchema(),
@@ -89,27 +87,33 @@ func getAuthenticationProfileSchema() map[string]*schema.Schema {
 }
 
 func resourceAuthenticationProfileExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking authentication profile exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking authentication profile exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewAuthenticationProfile(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Authentication profile exists in tenant: %s", object.ID)
+	logFor(m).Infof("Authentication profile exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceAuthenticationProfileImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewAuthenticationProfile(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceAuthenticationProfileRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading authentication profile: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading authentication profile: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a authentication profile object and populate ID attribute
 	object := vault.NewAuthenticationProfile(client)
@@ -119,7 +123,9 @@ func resourceAuthenticationProfileRead(d *schema.ResourceData, m interface{}) er
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading authentication profile: %v", err)
 	}
 	//logger.Debugf("Authentication profile from tenant: %v", object)
@@ -128,7 +134,7 @@ func resourceAuthenticationProfileRead(d *schema.ResourceData, m interface{}) er
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceAuthenticationProfileRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceAuthenticationProfileRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		if k == "additional_data" {
 			d.Set(k, flattenAdditionalData(object.AdditionalData))
@@ -137,13 +143,13 @@ func resourceAuthenticationProfileRead(d *schema.ResourceData, m interface{}) er
 		}
 	}
 
-	logger.Infof("Completed reading authentication profile: %s", object.Name)
+	logFor(m).Infof("Completed reading authentication profile: %s", object.Name)
 	return nil
 }
 
 func resourceAuthenticationProfileDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of authentication profile: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of authentication profile: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewAuthenticationProfile(client)
 	object.ID = d.Id()
@@ -159,14 +165,14 @@ func resourceAuthenticationProfileDelete(d *schema.ResourceData, m interface{})
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of authentication profile completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of authentication profile completed: %s", ResourceIDString(d))
 	return nil
 }
 
 func resourceAuthenticationProfileCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning authentication profile creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning authentication profile creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a authentication profile object and populate all attributes
 	object := vault.NewAuthenticationProfile(client)
@@ -185,15 +191,20 @@ func resourceAuthenticationProfileCreate(d *schema.ResourceData, m interface{})
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Creation completed
-	logger.Infof("Creation of authentication profile completed: %s", object.Name)
+	logFor(m).Infof("Creation of authentication profile completed: %s", object.Name)
 	return resourceAuthenticationProfileRead(d, m)
 }
 
 func resourceAuthenticationProfileUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning authentication profile update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning authentication profile update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewAuthenticationProfile(client)
 
 	object.ID = d.Id()
@@ -206,10 +217,10 @@ func resourceAuthenticationProfileUpdate(d *schema.ResourceData, m interface{})
 		if err != nil || !resp.Success {
 			return fmt.Errorf("error updating authentication profile attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
-	logger.Infof("Updating of authentication profile completed: %s", object.Name)
+	logFor(m).Infof("Updating of authentication profile completed: %s", object.Name)
 	return resourceAuthenticationProfileRead(d, m)
 }
 
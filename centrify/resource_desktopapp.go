@@ -2,14 +2,12 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/desktopapp/applicationtemplate"
 	"github.com/marcozj/golang-sdk/enum/desktopapp/cmdparamtype"
 	"github.com/marcozj/golang-sdk/enum/desktopapp/logincredential"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -22,7 +20,7 @@ func resourceDesktopApp_deprecated() *schema.Resource {
 		Delete: resourceDesktopAppDelete,
 		Exists: resourceDesktopAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDesktopAppImportID, "name"),
 		},
 
 		Schema:             getDesktopAppSchema(),
@@ -38,7 +36,7 @@ func resourceDesktopApp() *schema.Resource {
 		Delete: resourceDesktopAppDelete,
 		Exists: resourceDesktopAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDesktopAppImportID, "name"),
 		},
 
 		Schema: getDesktopAppSchema(),
@@ -178,27 +176,33 @@ func getDesktopAppSchema() map[string]*schema.Schema {
 }
 
 func resourceDesktopAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking DesktopApp exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking DesktopApp exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDesktopApp(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("DesktopApp exists in tenant: %s", object.ID)
+	logFor(m).Infof("DesktopApp exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceDesktopAppImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewDesktopApp(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceDesktopAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading DesktopApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading DesktopApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewDesktopApp object and populate ID attribute
 	object := vault.NewDesktopApp(client)
@@ -208,7 +212,9 @@ func resourceDesktopAppRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading DesktopApp: %v", err)
 	}
 	//logger.Debugf("DesktopApp from tenant: %+v", object)
@@ -216,7 +222,7 @@ func resourceDesktopAppRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceDesktopAppRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceDesktopAppRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -231,24 +237,24 @@ func resourceDesktopAppRead(d *schema.ResourceData, m interface{}) error {
 				d.Set(k, v)
 			}
 		case "command_parameter":
-			logger.Debugf("cmd pars: %+v", v)
+			logFor(m).Debugf("cmd pars: %+v", v)
 			d.Set(k, v)
 		default:
 			d.Set(k, v)
 		}
 	}
 
-	logger.Infof("Completed reading DesktopApp: %s", object.Name)
+	logFor(m).Infof("Completed reading DesktopApp: %s", object.Name)
 	return nil
 }
 
 func resourceDesktopAppCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning DesktopApp creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning DesktopApp creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a DesktopApp object
 	object := vault.NewDesktopApp(client)
@@ -270,6 +276,11 @@ func resourceDesktopAppCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Update attributes to complete creation
 	err = getUpateGetDesktopAppData(d, object)
 	if err != nil {
@@ -298,17 +309,17 @@ func resourceDesktopAppCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of DesktopApp completed: %s", object.Name)
+	logFor(m).Infof("Creation of DesktopApp completed: %s", object.Name)
 	return resourceDesktopAppRead(d, m)
 }
 
 func resourceDesktopAppUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning DesktopApp update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning DesktopApp update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewDesktopApp(client)
 	object.ID = d.Id()
 	err := getUpateGetDesktopAppData(d, object)
@@ -323,7 +334,7 @@ func resourceDesktopAppUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating DesktopApp attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -382,13 +393,13 @@ func resourceDesktopAppUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of DesktopApp completed: %s", object.Name)
+	logFor(m).Infof("Updating of DesktopApp completed: %s", object.Name)
 	return resourceDesktopAppRead(d, m)
 }
 
 func resourceDesktopAppDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of DesktopApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of DesktopApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDesktopApp(client)
 	object.ID = d.Id()
@@ -404,7 +415,7 @@ func resourceDesktopAppDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of DesktopApp completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of DesktopApp completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -2,10 +2,8 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -17,7 +15,7 @@ func resourceFederatedGroup_deprecated() *schema.Resource {
 		Delete: resourceFederatedGroupDelete,
 		Exists: resourceFederatedGroupExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceFederatedGroupImportID, "name"),
 		},
 
 		Schema:             getFederatedGroupSchema(),
@@ -35,7 +33,7 @@ func resourceFederatedGroup() *schema.Resource {
 		Delete: resourceFederatedGroupDelete,
 		Exists: resourceFederatedGroupExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceFederatedGroupImportID, "name"),
 		},
 
 		Schema: getFederatedGroupSchema(),
@@ -54,27 +52,33 @@ func getFederatedGroupSchema() map[string]*schema.Schema {
 }
 
 func resourceFederatedGroupExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking federated group exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking federated group exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewFederatedGroup(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Federated group exists in tenant: %s", object.ID)
+	logFor(m).Infof("Federated group exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceFederatedGroupImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewFederatedGroup(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceFederatedGroupRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading federated group: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading federated group: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a role object and populate ID attribute
 	object := vault.NewFederatedGroup(client)
@@ -84,28 +88,30 @@ func resourceFederatedGroupRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading federated group: %v", err)
 	}
-	logger.Debugf("Federated group from tenant: %v", object)
+	logFor(m).Debugf("Federated group from tenant: %v", object)
 
 	schemamap, err := vault.GenerateSchemaMap(object)
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceFederatedGroupRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceFederatedGroupRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading federated group: %s", object.Name)
+	logFor(m).Infof("Completed reading federated group: %s", object.Name)
 	return nil
 }
 
 func resourceFederatedGroupCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning federated group creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning federated group creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a role object and populate all attributes
 	object := vault.NewFederatedGroup(client)
@@ -124,12 +130,17 @@ func resourceFederatedGroupCreate(d *schema.ResourceData, m interface{}) error {
 	d.SetId(id)
 	object.ID = id
 
-	logger.Infof("Creation of federated group completed: %s", object.Name)
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
+	logFor(m).Infof("Creation of federated group completed: %s", object.Name)
 	return resourceFederatedGroupRead(d, m)
 }
 
 func resourceFederatedGroupDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Deletion of federated group isn't supported. Update terraform state only but not upstream application")
+	logFor(m).Infof("Deletion of federated group isn't supported. Update terraform state only but not upstream application")
 	d.SetId("")
 
 	return nil
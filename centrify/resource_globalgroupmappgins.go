@@ -6,17 +6,30 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
+// groupMappingsID is ID of the only global group mappings of tenant
+const groupMappingsID = "centrifyvault_global_group_mappings"
+
 func resourceGlobalGroupMappings_deprecated() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGroupMappingCreate,
 		Read:   resourceGroupMappingRead,
 		Update: resourceGroupMappingUpdate,
 		Delete: resourceGroupMappingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGroupMappingImport,
+		},
 
-		Schema:             getGroupMappingSchema(),
+		Schema:        getGroupMappingSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceGroupMappingV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGroupMappingStateUpgradeV0,
+			},
+		},
 		DeprecationMessage: "resource centrifyvault_globalgroupmappings is deprecated will be removed in the future, use centrify_globalgroupmappings instead",
 	}
 }
@@ -27,8 +40,19 @@ func resourceGlobalGroupMappings() *schema.Resource {
 		Read:   resourceGroupMappingRead,
 		Update: resourceGroupMappingUpdate,
 		Delete: resourceGroupMappingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGroupMappingImport,
+		},
 
-		Schema: getGroupMappingSchema(),
+		Schema:        getGroupMappingSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceGroupMappingV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGroupMappingStateUpgradeV0,
+			},
+		},
 	}
 }
 
@@ -47,33 +71,62 @@ func getGroupMappingSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
-		/*"mapping": {
-			Type:          schema.TypeSet,
-			Optional:      true,
-			Set:           customGroupMappingHash,
-			ConflictsWith: []string{"attribute_group"},
-			Deprecated:    "use attribute_group instead",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"attribute_value": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Group attribute value",
-					},
-					"group_name": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Group name",
+	}
+}
+
+// resourceGroupMappingV0 is global group mappings schema before version 1, in which mapping is a set of blocks as
+// before 0.2.2
+func resourceGroupMappingV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"bulkupdate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"mapping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      customGroupMappingHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute_value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Group attribute value",
+						},
+						"group_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Group name",
+						},
 					},
 				},
 			},
-		},*/
+		},
 	}
 }
 
+// resourceGroupMappingStateUpgradeV0 converts mapping blocks of state written before 0.2.2 to map of attribute
+// value to group name
+func resourceGroupMappingStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	migrateGroupMappingsAttributes(rawState)
+	return rawState, nil
+}
+
+// resourceGroupMappingImport imports current global group mappings of tenant
+func resourceGroupMappingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	results, err := importSingleton(groupMappingsID, "centrify_global_group_mappings")(d, m)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("bulkupdate", true)
+
+	return results, nil
+}
+
 func resourceGroupMappingRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading global group mappings: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading global group mappings: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewGroupMappings(client)
 	err := object.Read()
@@ -81,51 +134,30 @@ func resourceGroupMappingRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading global group mappings: %v", err)
 	}
 	//logger.Debugf("Global group mapping from tenant: %v", object)
 
-	schemamap, err := vault.GenerateSchemaMap(object)
-	if err != nil {
-		return err
-	}
-	logger.Debugf("Generated Map for resourceGroupMappingRead(): %+v", schemamap)
-	for k, v := range schemamap {
-		switch k {
-		case "attribute_group":
-			mappings := make(map[string]interface{})
-			for _, m := range v.([]interface{}) {
-				var mapkey, mapvalue string
-				for mk, mv := range m.(map[string]interface{}) {
-					if mk == "attribute_value" {
-						mapkey = mv.(string)
-					}
-					if mk == "group_name" {
-						mapvalue = mv.(string)
-					}
-				}
-				mappings[mapkey] = mapvalue
-			}
-
-			d.Set(k, mappings)
-		default:
-			d.Set(k, v)
-		}
+	// "mapping" is a map of attribute value to group name
+	mappings := make(map[string]interface{})
+	for _, v := range object.Mappings {
+		mappings[v.AttributeValue] = v.GroupName
 	}
+	d.Set("mapping", mappings)
 
-	//d.Set("mapping", object.Mappings)
-
-	logger.Infof("Completed reading global group mappings")
+	logFor(m).Infof("Completed reading global group mappings")
 	return nil
 }
 
 func resourceGroupMappingCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning global group mappings creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning global group mappings creation: %s", ResourceIDString(d))
 
-	d.SetId("centrifyvault_global_group_mappings")
+	d.SetId(groupMappingsID)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewGroupMappings(client)
 
 	createUpateGroupMappingsData(d, object)
@@ -141,16 +173,16 @@ func resourceGroupMappingCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Creation completed
-	logger.Infof("Creation of global group mappings completed: %s", d.Id())
+	logFor(m).Infof("Creation of global group mappings completed: %s", d.Id())
 	return resourceGroupMappingRead(d, m)
 }
 
 func resourceGroupMappingUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning global group mappings update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning global group mappings update: %s", ResourceIDString(d))
 
-	d.SetId("centrifyvault_global_group_mappings")
+	d.SetId(groupMappingsID)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewGroupMappings(client)
 
 	createUpateGroupMappingsData(d, object)
@@ -182,9 +214,9 @@ func resourceGroupMappingUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceGroupMappingDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of global group mappings: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning deletion of global group mappings: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewGroupMappings(client)
 	// We need to fill the mappings so that they can be deleted one by one
 	createUpateGroupMappingsData(d, object)
@@ -201,7 +233,7 @@ func resourceGroupMappingDelete(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId("")
 
-	logger.Infof("Deletion of global group mappings completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of global group mappings completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -2,15 +2,17 @@ package centrify
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/workflowtype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
+// globalWorkflowIDPrefix is followed by workflow type in ID of global workflow
+const globalWorkflowIDPrefix = "centrifyvault_global_workflow_"
+
 func resourceGlobalWorkflow_deprecated() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGlobalWorkflowCreate,
@@ -18,7 +20,7 @@ func resourceGlobalWorkflow_deprecated() *schema.Resource {
 		Update: resourceGlobalWorkflowUpdate,
 		Delete: resourceGlobalWorkflowDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceGlobalWorkflowImport,
 		},
 
 		Schema:             getGlobalWorkflowSchema(),
@@ -33,7 +35,7 @@ func resourceGlobalWorkflow() *schema.Resource {
 		Update: resourceGlobalWorkflowUpdate,
 		Delete: resourceGlobalWorkflowDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceGlobalWorkflowImport,
 		},
 
 		Schema: getGlobalWorkflowSchema(),
@@ -74,9 +76,27 @@ func getGlobalWorkflowSchema() map[string]*schema.Schema {
 	}
 }
 
+// resourceGlobalWorkflowImport imports global workflow using its type, such as wf or secretsWorkflow, or its ID
+func resourceGlobalWorkflowImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	wfType := strings.TrimPrefix(d.Id(), globalWorkflowIDPrefix)
+	types := []string{
+		workflowtype.AccountWorkflow.String(),
+		workflowtype.AgentAuthWorkflow.String(),
+		workflowtype.SecretsWorkflow.String(),
+		workflowtype.PrivilegeElevationWorkflow.String(),
+	}
+	if !contains(types, wfType) {
+		return nil, fmt.Errorf(" Invalid import ID %s, expected one of workflow types %s", d.Id(), strings.Join(types, ", "))
+	}
+	d.Set("type", wfType)
+	d.SetId(globalWorkflowIDPrefix + wfType)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGlobalWorkflowRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading global workflow: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading global workflow: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object, err := vault.NewGlobalWorkflow(client, d.Get("type").(string))
 	if err != nil {
@@ -88,7 +108,9 @@ func resourceGlobalWorkflowRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading global workflow %v", err)
 	}
 
@@ -96,19 +118,19 @@ func resourceGlobalWorkflowRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceGlobalWorkflowRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceGlobalWorkflowRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading global workflow: %s", object.Type)
+	logFor(m).Infof("Completed reading global workflow: %s", object.Type)
 	return nil
 }
 
 func resourceGlobalWorkflowCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning global workflow creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning global workflow creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object, err := vault.NewGlobalWorkflow(client, d.Get("type").(string))
 	if err != nil {
 		return err
@@ -121,19 +143,19 @@ func resourceGlobalWorkflowCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error creating global workflow: %v", err)
 	}
 
-	id := "centrifyvault_global_workflow_" + d.Get("type").(string)
+	id := globalWorkflowIDPrefix + d.Get("type").(string)
 	d.SetId(id)
 	object.ID = id
 
 	// Update completed
-	logger.Infof("Creation of global workflow completed: %s", d.Id())
+	logFor(m).Infof("Creation of global workflow completed: %s", d.Id())
 	return resourceGlobalWorkflowRead(d, m)
 }
 
 func resourceGlobalWorkflowUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning global workflow update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning global workflow update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object, err := vault.NewGlobalWorkflow(client, d.Get("type").(string))
 	if err != nil {
 		return err
@@ -149,13 +171,13 @@ func resourceGlobalWorkflowUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Updating of global workflow completed: %s", object.Type)
+	logFor(m).Infof("Updating of global workflow completed: %s", object.Type)
 	return resourceGlobalWorkflowRead(d, m)
 }
 
 func resourceGlobalWorkflowDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning disabling of global workflow: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning disabling of global workflow: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object, err := vault.NewGlobalWorkflow(client, d.Get("type").(string))
 	if err != nil {
@@ -169,7 +191,7 @@ func resourceGlobalWorkflowDelete(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId("")
 
-	logger.Infof("Disabling of global workflow completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Disabling of global workflow completed: %s", ResourceIDString(d))
 	return nil
 }
 
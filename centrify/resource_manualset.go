@@ -2,12 +2,10 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/settype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +18,7 @@ func resourceManualSet_deprecated() *schema.Resource {
 		Delete: resourceManualSetDelete,
 		Exists: resourceManualSetExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceManualSetImportID, "name", "type"),
 		},
 
 		Schema:             getManualSetSchema(),
@@ -36,7 +34,7 @@ func resourceManualSet() *schema.Resource {
 		Delete: resourceManualSetDelete,
 		Exists: resourceManualSetExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceManualSetImportID, "name", "type"),
 		},
 
 		Schema: getManualSetSchema(),
@@ -86,31 +84,39 @@ func getManualSetSchema() map[string]*schema.Schema {
 		},
 		"permission":        getPermissionSchema(),
 		"member_permission": getPermissionSchema(),
+		"adopt_existing":    getAdoptExistingSchema(),
 	}
 }
 
 func resourceManualSetExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Manual Set exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Manual Set exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewManualSet(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Manual Set exists in tenant: %s", object.ID)
+	logFor(m).Infof("Manual Set exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceManualSetImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewManualSet(client)
+	object.Name = keys["name"]
+	object.ObjectType = keys["type"]
+	return queryResultID(object.Query())
+}
+
 func resourceManualSetRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Manual Set: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Manual Set: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a Manual Set object and populate ID attribute
 	object := vault.NewManualSet(client)
@@ -120,7 +126,9 @@ func resourceManualSetRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Manual Set: %v", err)
 	}
 	//logger.Debugf("Manual Set from tenant: %v", object)
@@ -129,22 +137,33 @@ func resourceManualSetRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceManualSetRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceManualSetRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading Manual Set: %s", object.Name)
+	logFor(m).Infof("Completed reading Manual Set: %s", object.Name)
 	return nil
 }
 
 func resourceManualSetCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Manual Set creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Manual Set creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := vault.NewManualSetWithType(client, d.Get("type").(string))
+		if err != nil {
+			return err
+		}
+		existing.Name = d.Get("name").(string)
+		if adopted, err := adoptExisting(d, m, "Manual Set", existing.GetIDByName, resourceManualSetUpdate); adopted || err != nil {
+			return err
+		}
+	}
 
 	// Create a manual set object and populate all attributes
 	object, err := vault.NewManualSetWithType(client, d.Get("type").(string))
@@ -170,6 +189,11 @@ func resourceManualSetCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Handle Set permissions
 	if _, ok := d.GetOk("permission"); ok {
 
@@ -189,17 +213,17 @@ func resourceManualSetCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Manual Set completed: %s", object.Name)
+	logFor(m).Infof("Creation of Manual Set completed: %s", object.Name)
 	return resourceManualSetRead(d, m)
 }
 
 func resourceManualSetUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Manual Set update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Manual Set update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object, err := vault.NewManualSetWithType(client, d.Get("type").(string))
 	if err != nil {
 		return err
@@ -282,13 +306,13 @@ func resourceManualSetUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Manual Set completed: %s", object.Name)
+	logFor(m).Infof("Updating of Manual Set completed: %s", object.Name)
 	return resourceManualSetRead(d, m)
 }
 
 func resourceManualSetDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Manual Set: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Manual Set: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewManualSet(client)
 	object.ID = d.Id()
@@ -304,7 +328,7 @@ func resourceManualSetDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Manual Set completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Manual Set completed: %s", ResourceIDString(d))
 	return nil
 }
 
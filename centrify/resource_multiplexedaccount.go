@@ -2,10 +2,8 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -18,7 +16,7 @@ func resourceMultiplexedAccount_deprecated() *schema.Resource {
 		Delete: resourceMultiplexedAccountDelete,
 		Exists: resourceMultiplexedAccountExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceMultiplexedAccountImportID, "name"),
 		},
 
 		Schema:             getMultiplexedAccountSchema(),
@@ -34,7 +32,7 @@ func resourceMultiplexedAccount() *schema.Resource {
 		Delete: resourceMultiplexedAccountDelete,
 		Exists: resourceMultiplexedAccountExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceMultiplexedAccountImportID, "name"),
 		},
 
 		Schema: getMultiplexedAccountSchema(),
@@ -93,27 +91,33 @@ func getMultiplexedAccountSchema() map[string]*schema.Schema {
 }
 
 func resourceMultiplexedAccountExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking multiplexed account exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking multiplexed account exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewMultiplexedAccount(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Multiplexed account exists in tenant: %s", object.ID)
+	logFor(m).Infof("Multiplexed account exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceMultiplexedAccountImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewMultiplexedAccount(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceMultiplexedAccountRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading multiplexed account: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading multiplexed account: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewMultiplexedAccount object and populate ID attribute
 	object := vault.NewMultiplexedAccount(client)
@@ -123,7 +127,9 @@ func resourceMultiplexedAccountRead(d *schema.ResourceData, m interface{}) error
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading multiplexed account: %v", err)
 	}
 	//logger.Debugf("Multiplexed account from tenant: %+v", object)
@@ -131,22 +137,22 @@ func resourceMultiplexedAccountRead(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceMultiplexedAccountRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceMultiplexedAccountRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Debugf("Completed reading multiplexed account: %s", object.Name)
+	logFor(m).Debugf("Completed reading multiplexed account: %s", object.Name)
 	return nil
 }
 
 func resourceMultiplexedAccountCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning multiplexed account creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning multiplexed account creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a multiplexed account object and populate all attributes
 	object := vault.NewMultiplexedAccount(client)
@@ -168,6 +174,11 @@ func resourceMultiplexedAccountCreate(d *schema.ResourceData, m interface{}) err
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// add permissions
 	if _, ok := d.GetOk("permission"); ok {
 		_, err = object.SetPermissions(false)
@@ -178,17 +189,17 @@ func resourceMultiplexedAccountCreate(d *schema.ResourceData, m interface{}) err
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of multiplexed account completed: %s", object.Name)
+	logFor(m).Infof("Creation of multiplexed account completed: %s", object.Name)
 	return resourceMultiplexedAccountRead(d, m)
 }
 
 func resourceMultiplexedAccountUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning multiplexed account update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning multiplexed account update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewMultiplexedAccount(client)
 	object.ID = d.Id()
 	err := createUpateGetMultiplexedAccountData(d, object)
@@ -202,7 +213,7 @@ func resourceMultiplexedAccountUpdate(d *schema.ResourceData, m interface{}) err
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating multiplexed account attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	// Deal with Permissions
@@ -237,13 +248,13 @@ func resourceMultiplexedAccountUpdate(d *schema.ResourceData, m interface{}) err
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of multiplexed account completed: %s", object.Name)
+	logFor(m).Infof("Updating of multiplexed account completed: %s", object.Name)
 	return resourceMultiplexedAccountRead(d, m)
 }
 
 func resourceMultiplexedAccountDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of multiplexed account: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of multiplexed account: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewMultiplexedAccount(client)
 	object.ID = d.Id()
@@ -260,7 +271,7 @@ func resourceMultiplexedAccountDelete(d *schema.ResourceData, m interface{}) err
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of multiplexed account completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of multiplexed account completed: %s", ResourceIDString(d))
 	return nil
 }
 
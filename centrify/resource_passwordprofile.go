@@ -2,11 +2,9 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +18,7 @@ func resourcePasswordProfile_deprecated() *schema.Resource {
 		Delete: resourcePasswordProfileDelete,
 		Exists: resourcePasswordProfileExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourcePasswordProfileImportID, "name"),
 		},
 
 		Schema:             getPasswordProfileSchema(),
@@ -37,7 +35,7 @@ func resourcePasswordProfile() *schema.Resource {
 		Delete: resourcePasswordProfileDelete,
 		Exists: resourcePasswordProfileExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourcePasswordProfileImportID, "name"),
 		},
 
 		Schema: getPasswordProfileSchema(),
@@ -146,27 +144,33 @@ func getPasswordProfileSchema() map[string]*schema.Schema {
 }
 
 func resourcePasswordProfileExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking password profile exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking password profile exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewPasswordProfile(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Authentication password exists in tenant: %s", object.ID)
+	logFor(m).Infof("Authentication password exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourcePasswordProfileImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewPasswordProfile(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourcePasswordProfileRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading password profile: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading password profile: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a password profile object and populate ID attribute
 	object := vault.NewPasswordProfile(client)
@@ -176,7 +180,9 @@ func resourcePasswordProfileRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading password profile: %v", err)
 	}
 	//logger.Debugf("password profile from tenant: %v", object)
@@ -185,18 +191,18 @@ func resourcePasswordProfileRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourcePasswordProfileRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourcePasswordProfileRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading password profile: %s", object.Name)
+	logFor(m).Infof("Completed reading password profile: %s", object.Name)
 	return nil
 }
 
 func resourcePasswordProfileDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of password profile: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of password profile: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewPasswordProfile(client)
 	object.ID = d.Id()
@@ -212,14 +218,14 @@ func resourcePasswordProfileDelete(d *schema.ResourceData, m interface{}) error
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of password profile completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of password profile completed: %s", ResourceIDString(d))
 	return nil
 }
 
 func resourcePasswordProfileCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning password profile creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning password profile creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a password profile object and populate all attributes
 	object := vault.NewPasswordProfile(client)
@@ -238,15 +244,20 @@ func resourcePasswordProfileCreate(d *schema.ResourceData, m interface{}) error
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Creation completed
-	logger.Infof("Creation of password profile completed: %s", object.Name)
+	logFor(m).Infof("Creation of password profile completed: %s", object.Name)
 	return resourcePasswordProfileRead(d, m)
 }
 
 func resourcePasswordProfileUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning password profile update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning password profile update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewPasswordProfile(client)
 
 	object.ID = d.Id()
@@ -260,10 +271,10 @@ func resourcePasswordProfileUpdate(d *schema.ResourceData, m interface{}) error
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating password profile attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
-	logger.Infof("Updating of password profile completed: %s", object.Name)
+	logFor(m).Infof("Updating of password profile completed: %s", object.Name)
 	return resourcePasswordProfileRead(d, m)
 }
 
@@ -19,7 +19,7 @@ func resourcePolicy_deprecated() *schema.Resource {
 		Delete: resourcePolicyDelete,
 		Exists: resourcePolicyExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourcePolicyImportID, "name"),
 		},
 
 		Schema:             getPolicySchema(),
@@ -35,7 +35,7 @@ func resourcePolicy() *schema.Resource {
 		Delete: resourcePolicyDelete,
 		Exists: resourcePolicyExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourcePolicyImportID, "name"),
 		},
 
 		Schema: getPolicySchema(),
@@ -111,27 +111,37 @@ func getPolicySchema() map[string]*schema.Schema {
 }
 
 func resourcePolicyExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking policy exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking policy exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewPolicy(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Policy exists in tenant: %s", object.ID)
+	logFor(m).Infof("Policy exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourcePolicyImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewPolicy(client)
+	object.Name = keys["name"]
+	if _, err := object.Query("name"); err != nil {
+		return "", err
+	}
+	// Policy ID is in "/Policy/<name>" format
+	return "/Policy/" + object.Name, nil
+}
+
 func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading policy: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading policy: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a policy object and populate ID attribute
 	object := vault.NewPolicy(client)
@@ -141,7 +151,9 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading policy: %v", err)
 	}
 	//logger.Debugf("Policy from tenant: %v", object)
@@ -150,7 +162,7 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourcePolicyRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourcePolicyRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "path":
@@ -188,13 +200,13 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading policy: %s", object.Name)
+	logFor(m).Infof("Completed reading policy: %s", object.Name)
 	return nil
 }
 
 func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of policy: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of policy: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewPolicy(client)
 	object.ID = d.Id()
@@ -211,14 +223,14 @@ func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of policy completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of policy completed: %s", ResourceIDString(d))
 	return nil
 }
 
 func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning policy creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning policy creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a policy object and populate all attributes
 	object := vault.NewPolicy(client)
@@ -241,15 +253,20 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Creation completed
-	logger.Infof("Creation of policy completed: %s", object.Name)
+	logFor(m).Infof("Creation of policy completed: %s", object.Name)
 	return resourcePolicyRead(d, m)
 }
 
 func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning policy update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning policy update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewPolicy(client)
 
 	object.ID = d.Id()
@@ -265,10 +282,10 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating policy attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
-	logger.Infof("Updating of policy completed: %s", object.Name)
+	logFor(m).Infof("Updating of policy completed: %s", object.Name)
 	return resourcePolicyRead(d, m)
 }
 
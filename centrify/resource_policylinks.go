@@ -4,11 +4,12 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
+// policyLinksID is ID of the only policy order of tenant
+const policyLinksID = "centrifyvault_policy_links"
+
 func resourcePolicyLinks_deprecated() *schema.Resource {
 	return &schema.Resource{
 		Create: resourcePolicyLinksCreate,
@@ -16,7 +17,7 @@ func resourcePolicyLinks_deprecated() *schema.Resource {
 		Update: resourcePolicyLinksUpdate,
 		Delete: resourcePolicyLinksDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importSingleton(policyLinksID, "centrify_policy_links"),
 		},
 
 		Schema:             getPolicyLinksSchema(),
@@ -31,7 +32,7 @@ func resourcePolicyLinks() *schema.Resource {
 		Update: resourcePolicyLinksUpdate,
 		Delete: resourcePolicyLinksDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importSingleton(policyLinksID, "centrify_policy_links"),
 		},
 
 		Schema: getPolicyLinksSchema(),
@@ -51,8 +52,8 @@ func getPolicyLinksSchema() map[string]*schema.Schema {
 }
 
 func resourcePolicyLinksRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading policy links: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading policy links: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create policy links object
 	object := vault.NewPolicyLinks(client)
@@ -61,7 +62,9 @@ func resourcePolicyLinksRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading policy: %v", err)
 	}
 
@@ -76,11 +79,11 @@ func resourcePolicyLinksRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourcePolicyLinksCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning policy links creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning policy links creation: %s", ResourceIDString(d))
 
-	d.SetId("centrifyvault_policy_links")
+	d.SetId(policyLinksID)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewPolicyLinks(client)
 
 	// Upon creating policy links in local state, update the order in tenant as well
@@ -96,14 +99,14 @@ func resourcePolicyLinksCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Creation completed
-	logger.Infof("Creation of policy links completed: %s", d.Id())
+	logFor(m).Infof("Creation of policy links completed: %s", d.Id())
 	return resourcePolicyLinksRead(d, m)
 }
 
 func resourcePolicyLinksUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning policy links update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning policy links update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewPolicyLinks(client)
 
 	ids := d.Get("policy_order").([]interface{})
@@ -124,11 +127,11 @@ func resourcePolicyLinksUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourcePolicyLinksDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of policy links: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning deletion of policy links: %s", ResourceIDString(d))
 
 	// We do not actually delete anything from the tenant
 	d.SetId("")
 
-	logger.Infof("Deletion of policy links completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of policy links completed: %s", ResourceIDString(d))
 	return nil
 }
@@ -3,11 +3,9 @@ package centrify
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +18,7 @@ func resourceRole_deprecated() *schema.Resource {
 		Delete: resourceRoleDelete,
 		Exists: resourceRoleExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceRoleImportID, "name"),
 		},
 
 		Schema:             getRoleSchema(),
@@ -36,7 +34,7 @@ func resourceRole() *schema.Resource {
 		Delete: resourceRoleDelete,
 		Exists: resourceRoleExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceRoleImportID, "name"),
 		},
 
 		Schema: getRoleSchema(),
@@ -92,30 +90,37 @@ func getRoleSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"adopt_existing": getAdoptExistingSchema(),
 	}
 }
 func resourceRoleExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking role exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking role exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewRole(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Role exists in tenant: %s", object.ID)
+	logFor(m).Infof("Role exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceRoleImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewRole(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading role: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading role: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a role object and populate ID attribute
 	object := vault.NewRole(client)
@@ -125,31 +130,41 @@ func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading role: %v", err)
 	}
-	logger.Debugf("Role from tenant: %v", object)
+	logFor(m).Debugf("Role from tenant: %v", object)
 
 	schemamap, err := vault.GenerateSchemaMap(object)
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceRoleRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceRoleRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading role: %s", object.Name)
+	logFor(m).Infof("Completed reading role: %s", object.Name)
 	return nil
 }
 
 func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Role creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Role creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
+
+	if d.Get("adopt_existing").(bool) {
+		existing := vault.NewRole(client)
+		existing.Name = d.Get("name").(string)
+		if adopted, err := adoptExisting(d, m, "role", existing.GetIDByName, resourceRoleUpdate); adopted || err != nil {
+			return err
+		}
+	}
 
 	// Create a role object and populate all attributes
 	object := vault.NewRole(client)
@@ -170,7 +185,12 @@ func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute otherwise AssignAdminRights function will fail
 	object.ID = id
 
-	logger.Debugf("Role created: %s", object.Name)
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
+	logFor(m).Debugf("Role created: %s", object.Name)
 
 	// Handle role members
 	if len(object.Members) > 0 {
@@ -187,22 +207,22 @@ func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
 			log.Fatalf("Error updating role admin rights: %v", err)
 			return nil
 		}
-		logger.Debugf("Updated admin rights to: %v", object.AdminRights)
+		logFor(m).Debugf("Updated admin rights to: %v", object.AdminRights)
 	}
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of role completed: %s", object.Name)
+	logFor(m).Infof("Creation of role completed: %s", object.Name)
 	return resourceRoleRead(d, m)
 }
 
 func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning role update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning role update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewRole(client)
 	object.ID = d.Id()
 	createUpateGetRoleData(d, object)
@@ -212,7 +232,7 @@ func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating role attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
 	// Deal with role members
@@ -237,14 +257,14 @@ func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil {
 			return fmt.Errorf(" Error getting existing role admin rights: %v", err)
 		}
-		logger.Debugf("Removing existing admin rights: %v", rights)
+		logFor(m).Debugf("Removing existing admin rights: %v", rights)
 		if rights != nil && len(rights) > 0 {
 			resp, err := object.RemoveAdminRights(rights)
 			if err != nil || !resp.Success {
 				return fmt.Errorf(" Error removing existing role admin rights: %v", err)
 			}
 		}
-		logger.Debugf("Removed existing admin rights: %v", rights)
+		logFor(m).Debugf("Removed existing admin rights: %v", rights)
 
 		// Set new admin rights
 		if d.Get("adminrights") != nil && d.Get("adminrights").(*schema.Set).Len() > 0 {
@@ -254,25 +274,25 @@ func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
 				adminrights[i] = tfAdminRight.(string)
 			}
 			object.AdminRights = adminrights
-			logger.Debugf("Adding admin rights: %v", adminrights)
+			logFor(m).Debugf("Adding admin rights: %v", adminrights)
 
 			resp, err := object.AssignAdminRights()
 			if err != nil || !resp.Success {
 				return fmt.Errorf(" Error updating role admin rights: %v", err)
 			}
-			logger.Debugf("Updated admin rights to: %v", adminrights)
+			logFor(m).Debugf("Updated admin rights to: %v", adminrights)
 		}
 	}
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of role completed: %s", object.Name)
+	logFor(m).Infof("Updating of role completed: %s", object.Name)
 	return resourceRoleRead(d, m)
 }
 
 func resourceRoleDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of role: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of role: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewRole(client)
 	object.ID = d.Id()
@@ -288,7 +308,7 @@ func resourceRoleDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of role completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of role completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -5,9 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func resourceRoleMembership_deprecated() *schema.Resource {
@@ -17,7 +15,7 @@ func resourceRoleMembership_deprecated() *schema.Resource {
 		Update: resourceRoleMembershipUpdate,
 		Delete: resourceRoleMembershipDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceRoleMembershipImport,
 		},
 
 		Schema:             getRoleMembershipSchema(),
@@ -32,7 +30,7 @@ func resourceRoleMembership() *schema.Resource {
 		Update: resourceRoleMembershipUpdate,
 		Delete: resourceRoleMembershipDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceRoleMembershipImport,
 		},
 
 		Schema: getRoleMembershipSchema(),
@@ -80,9 +78,20 @@ func getRoleMembershipSchema() map[string]*schema.Schema {
 	}
 }
 
+// resourceRoleMembershipImport imports all current members of a role using ID or name of the role
+func resourceRoleMembershipImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	results, err := importStateByName(resourceRoleImportID, "name")(d, m)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("role_id", d.Id())
+
+	return results, nil
+}
+
 func resourceRoleMembershipRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading role membership: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading role membership: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a role object and populate ID attribute
 	object := vault.NewRoleMembership(client)
@@ -93,31 +102,33 @@ func resourceRoleMembershipRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading role: %v", err)
 	}
-	logger.Debugf("Role from tenant: %v", object)
+	logFor(m).Debugf("Role from tenant: %v", object)
 
 	schemamap, err := vault.GenerateSchemaMap(object)
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceRoleMembershipRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceRoleMembershipRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading role membership: %s", object.Name)
+	logFor(m).Infof("Completed reading role membership: %s", object.Name)
 	return nil
 }
 
 func resourceRoleMembershipCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning role membership creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning role membership creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a role object and populate all attributes
 	object := vault.NewRoleMembership(client)
@@ -135,17 +146,17 @@ func resourceRoleMembershipCreate(d *schema.ResourceData, m interface{}) error {
 	d.SetId(object.RoleID)
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of role membership completed: %s", object.Name)
+	logFor(m).Infof("Creation of role membership completed: %s", object.Name)
 	return resourceRoleMembershipRead(d, m)
 }
 
 func resourceRoleMembershipUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning role membership update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning role membership update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewRoleMembership(client)
 	object.ID = d.Id()
 	createUpateGetRoleMembershipData(d, object)
@@ -167,13 +178,13 @@ func resourceRoleMembershipUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of role membership completed: %s", object.Name)
+	logFor(m).Infof("Updating of role membership completed: %s", object.Name)
 	return resourceRoleMembershipRead(d, m)
 }
 
 func resourceRoleMembershipDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of role membership: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of role membership: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewRoleMembership(client)
 	object.ID = d.Id()
@@ -187,7 +198,7 @@ func resourceRoleMembershipDelete(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId("")
-	logger.Infof("Deletion of role membership completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of role membership completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func TestAccResourceRoleCreation(t *testing.T) {
@@ -37,7 +36,7 @@ func testAccBasicDataExists(rName string) string {
 }
 
 func testAccCheckRoleDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*restapi.RestClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 	object := vault.NewUser(client)
 	for _, res := range s.RootModule().Resources {
 		if res.Type != "centrify_role" {
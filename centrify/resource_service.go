@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/servicetype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +19,7 @@ func resourceService_deprecated() *schema.Resource {
 		Delete: resourceServiceDelete,
 		Exists: resourceServiceExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceServiceImportID, "name"),
 		},
 
 		Schema:             getServiceSchema(),
@@ -36,7 +35,7 @@ func resourceService() *schema.Resource {
 		Delete: resourceServiceDelete,
 		Exists: resourceServiceExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceServiceImportID, "name"),
 		},
 
 		Schema: getServiceSchema(),
@@ -139,27 +138,33 @@ func getServiceSchema() map[string]*schema.Schema {
 }
 
 func resourceServiceExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking service exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking service exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewService(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Service exists in tenant: %s", object.ID)
+	logFor(m).Infof("Service exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceServiceImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewService(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceServiceRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading service: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading service: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewService object and populate ID attribute
 	object := vault.NewService(client)
@@ -169,7 +174,9 @@ func resourceServiceRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading service: %v", err)
 	}
 	//logger.Debugf("Service from tenant: %+v", object)
@@ -177,7 +184,7 @@ func resourceServiceRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceServiceRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceServiceRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		if k == "days_of_week" {
 			// Convert "value1,value1" to schema.TypeSet
@@ -187,17 +194,17 @@ func resourceServiceRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading service: %s", object.Name)
+	logFor(m).Infof("Completed reading service: %s", object.Name)
 	return nil
 }
 
 func resourceServiceCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning service creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning service creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a service object and populate all attributes
 	object := vault.NewService(client)
@@ -219,6 +226,11 @@ func resourceServiceCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to add service to Sets
 	if len(object.Sets) > 0 {
 		err := object.AddToSetsByID(object.Sets)
@@ -237,17 +249,17 @@ func resourceServiceCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of service completed: %s", object.Name)
+	logFor(m).Infof("Creation of service completed: %s", object.Name)
 	return resourceServiceRead(d, m)
 }
 
 func resourceServiceUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning service update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning service update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewService(client)
 	object.ID = d.Id()
 	err := createUpateGetServiceData(d, object)
@@ -262,7 +274,7 @@ func resourceServiceUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating service attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	// Deal with Set member
@@ -322,13 +334,13 @@ func resourceServiceUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of service completed: %s", object.Name)
+	logFor(m).Infof("Updating of service completed: %s", object.Name)
 	return resourceServiceRead(d, m)
 }
 
 func resourceServiceDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of service: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of service: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewService(client)
 	object.ID = d.Id()
@@ -345,7 +357,7 @@ func resourceServiceDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of service completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of service completed: %s", ResourceIDString(d))
 	return nil
 }
 
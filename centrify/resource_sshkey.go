@@ -2,10 +2,8 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -18,7 +16,7 @@ func resourceSSHKey_deprecated() *schema.Resource {
 		Delete: resourceSSHKeyDelete,
 		Exists: resourceSSHKeyExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSSHKeyImportID, "name"),
 		},
 
 		Schema:             getSSHKeySchema(),
@@ -34,7 +32,7 @@ func resourceSSHKey() *schema.Resource {
 		Delete: resourceSSHKeyDelete,
 		Exists: resourceSSHKeyExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSSHKeyImportID, "name"),
 		},
 
 		Schema: getSSHKeySchema(),
@@ -92,27 +90,33 @@ func getSSHKeySchema() map[string]*schema.Schema {
 }
 
 func resourceSSHKeyExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking SSH Key exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking SSH Key exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSSHKey(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("SSH Key exists in tenant: %s", object.ID)
+	logFor(m).Infof("SSH Key exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceSSHKeyImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewSSHKey(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading SSH Key: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading SSH Key: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a new SSHKey object and populate ID attribute
 	object := vault.NewSSHKey(client)
@@ -122,7 +126,9 @@ func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading SSH Key: %v", err)
 	}
 	//logger.Debugf("SSH Key from tenant: %+v", object)
@@ -130,7 +136,7 @@ func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceSSHKeyRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceSSHKeyRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -140,17 +146,17 @@ func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading SSH Key: %s", object.Name)
+	logFor(m).Infof("Completed reading SSH Key: %s", object.Name)
 	return nil
 }
 
 func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SSH Key creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SSH Key creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a SSH Key object and populate all attributes
 	object := vault.NewSSHKey(client)
@@ -172,6 +178,11 @@ func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update challenge login profile
 	// Create API call doesn't set challenge profile so need to run update again
 	if object.SSHKeysDefaultProfileID != "" || object.ChallengeRules != nil {
@@ -199,17 +210,17 @@ func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of SSH Key completed: %s", object.Name)
+	logFor(m).Infof("Creation of SSH Key completed: %s", object.Name)
 	return resourceSSHKeyRead(d, m)
 }
 
 func resourceSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SSH Key update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SSH Key update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewSSHKey(client)
 	object.ID = d.Id()
 	err := createUpateGetSSHKeyData(d, object)
@@ -227,7 +238,7 @@ func resourceSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating SSH Key attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -286,13 +297,13 @@ func resourceSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of SSH Key completed: %s", object.Name)
+	logFor(m).Infof("Updating of SSH Key completed: %s", object.Name)
 	return resourceSSHKeyRead(d, m)
 }
 
 func resourceSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of SSH Key: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of SSH Key: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSSHKey(client)
 	object.ID = d.Id()
@@ -319,7 +330,7 @@ func resourceSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of SSH Key completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of SSH Key completed: %s", ResourceIDString(d))
 	return nil
 }
 
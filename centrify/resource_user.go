@@ -2,10 +2,8 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -18,7 +16,7 @@ func resourceUser_deprecated() *schema.Resource {
 		Delete: resourceUserDelete,
 		Exists: resourceUserExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceUserImportID, "name"),
 		},
 
 		Schema:             getUserSchema(),
@@ -34,7 +32,7 @@ func resourceUser() *schema.Resource {
 		Delete: resourceUserDelete,
 		Exists: resourceUserExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceUserImportID, "name"),
 		},
 
 		Schema: getUserSchema(),
@@ -137,27 +135,33 @@ func getUserSchema() map[string]*schema.Schema {
 }
 
 func resourceUserExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking user exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking user exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewUser(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("User exists in tenant: %s", object.ID)
+	logFor(m).Infof("User exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceUserImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewUser(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceUserRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading user: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading user: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewUser object and populate ID attribute
 	object := vault.NewUser(client)
@@ -167,7 +171,9 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading user: %v", err)
 	}
 	//logger.Debugf("User from tenant: %+v", object)
@@ -175,21 +181,21 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceUserRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceUserRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading user: %s", object.Name)
+	logFor(m).Infof("Completed reading user: %s", object.Name)
 	return nil
 }
 
 func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning user creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning user creation: %s", ResourceIDString(d))
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a NewUser object and populate all attributes
 	object := vault.NewUser(client)
@@ -208,6 +214,11 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2rd step to add system to Sets
 	if len(object.Roles) > 0 {
 		for _, v := range object.Roles {
@@ -222,17 +233,17 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of user completed: %s", object.Name)
+	logFor(m).Infof("Creation of user completed: %s", object.Name)
 	return resourceUserRead(d, m)
 }
 
 func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning user update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning user update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewUser(client)
 	object.ID = d.Id()
 	createUpateGetUserData(d, object)
@@ -251,7 +262,7 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating user attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
 	// Change password
@@ -287,13 +298,13 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of user completed: %s", object.Name)
+	logFor(m).Infof("Updating of user completed: %s", object.Name)
 	return resourceUserRead(d, m)
 }
 
 func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of user: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of user: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewUser(client)
 	object.ID = d.Id()
@@ -309,7 +320,7 @@ func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of user completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of user completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func TestAccResourceUserCreation(t *testing.T) {
@@ -34,6 +33,38 @@ func TestAccResourceUserCreation(t *testing.T) {
 	})
 }
 
+func TestAccResourceUser_disappears(t *testing.T) {
+	rName := acctest.RandomWithPrefix("test-user@")
+	resourceName := "centrify_user.testuser"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccBasicValExists(rName),
+				Check:              testAccCheckUserDisappears(resourceName),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckUserDisappears deletes user outside of Terraform
+func testAccCheckUserDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		res, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+		client := testAccProvider.Meta().(*providerMeta).client
+		object := vault.NewUser(client)
+		object.ID = res.Primary.ID
+		_, err := object.Delete()
+		return err
+	}
+}
+
 func testAccBasicValExists(rName string) string {
 
 	return fmt.Sprintf(`resource "centrify_user" "testuser" {
@@ -46,7 +77,7 @@ func testAccBasicValExists(rName string) string {
 }
 
 func testAccCheckUserDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*restapi.RestClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 	object := vault.NewUser(client)
 	for _, res := range s.RootModule().Resources {
 		if res.Type != "centrify_user" {
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func resourceUserPassword_deprecated() *schema.Resource {
@@ -50,8 +48,8 @@ func getUserPasswordSchema() map[string]*schema.Schema {
 }
 
 func resourceUserPasswordRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading user password: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading user password: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewUser object and populate ID attribute
 	object := vault.NewUser(client)
@@ -61,7 +59,9 @@ func resourceUserPasswordRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading user: %v", err)
 	}
 	//logger.Debugf("User from tenant: %+v", object)
@@ -69,18 +69,18 @@ func resourceUserPasswordRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceUserPasswordRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceUserPasswordRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading user password: %s", object.Name)
+	logFor(m).Infof("Completed reading user password: %s", object.Name)
 	return nil
 }
 
 func resourceUserPasswordCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning user password creation: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning user password creation: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewUser object and populate all attributes
 	object := vault.NewUser(client)
@@ -97,14 +97,14 @@ func resourceUserPasswordCreate(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(object.ID)
 
-	logger.Infof("Creation of user password completed: %s", object.Name)
+	logFor(m).Infof("Creation of user password completed: %s", object.Name)
 	return resourceUserPasswordRead(d, m)
 }
 
 func resourceUserPasswordUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning user password update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning user password update: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewUser(client)
 	object.ID = d.Id()
 	createUpateGetUserPasswordData(d, object)
@@ -117,19 +117,19 @@ func resourceUserPasswordUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Updating of user password completed: %s", object.Name)
+	logFor(m).Infof("Updating of user password completed: %s", object.Name)
 	return resourceUserPasswordRead(d, m)
 }
 
 func resourceUserPasswordDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of user: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of user: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewUser(client)
 	object.ID = d.Id()
 
 	d.SetId("")
-	logger.Infof("Deletion of user password completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of user password completed: %s", ResourceIDString(d))
 	return nil
 }
 
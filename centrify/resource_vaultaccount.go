@@ -2,11 +2,10 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
+	"github.com/marcozj/golang-sdk/enum/resourcetype"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -19,7 +18,7 @@ func resourceAccount_deprecated() *schema.Resource {
 		Delete: resourceAccountDelete,
 		Exists: resourceAccountExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceAccountImportID, "account", "host", "domain", "database", "cloudprovider"),
 		},
 
 		Schema:             getAccountSchema(),
@@ -38,7 +37,7 @@ func resourceAccount() *schema.Resource {
 		Delete: resourceAccountDelete,
 		Exists: resourceAccountExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceAccountImportID, "account", "host", "domain", "database", "cloudprovider"),
 		},
 
 		Schema: getAccountSchema(),
@@ -181,27 +180,49 @@ func getAccountSchema() map[string]*schema.Schema {
 }
 
 func resourceAccountExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Account exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Account exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewAccount(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Account exists in tenant: %s", object.ID)
+	logFor(m).Infof("Account exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceAccountImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewAccount(client)
+	object.User = keys["account"]
+	switch {
+	case keys["host"] != "":
+		object.ResourceType = resourcetype.System.String()
+		object.ResourceName = keys["host"]
+	case keys["domain"] != "":
+		object.ResourceType = resourcetype.Domain.String()
+		object.ResourceName = keys["domain"]
+	case keys["database"] != "":
+		object.ResourceType = resourcetype.Database.String()
+		object.ResourceName = keys["database"]
+	case keys["cloudprovider"] != "":
+		object.ResourceType = resourcetype.CloudProvider.String()
+		object.ResourceName = keys["cloudprovider"]
+	default:
+		return "", fmt.Errorf(" Name of host, domain, database or cloudprovider the account belongs to must be provided")
+	}
+	return object.GetIDByName()
+}
+
 func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Account: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Account: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewAccount object and populate ID attribute
 	object := vault.NewAccount(client)
@@ -211,7 +232,9 @@ func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Account: %v", err)
 	}
 	//logger.Debugf("Account from tenant: %+v", object)
@@ -219,7 +242,7 @@ func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceAccountRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceAccountRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule", "access_secret_checkout_rule":
@@ -244,18 +267,18 @@ func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Account: %s", object.Name)
+	logFor(m).Infof("Completed reading Account: %s", object.Name)
 	return nil
 }
 
 func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Account creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Account creation: %s", ResourceIDString(d))
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create an Account object and populate all attributes
 	object := vault.NewAccount(client)
-	err := createUpateGetAccountData(d, object)
+	err := createUpateGetAccountData(d, m, object)
 	if err != nil {
 		return err
 	}
@@ -273,6 +296,11 @@ func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update password checkout profile
 	// Create API call doesn't set challenge profile so need to run update again
 	if object.PasswordCheckoutDefaultProfile != "" {
@@ -308,7 +336,7 @@ func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
 
 	// add IAM account access key
 	if len(object.AccessKeys) > 0 {
-		logger.Debugf("Adding access key...")
+		logFor(m).Debugf("Adding access key...")
 		for _, v := range object.AccessKeys {
 			err := object.SafeAddAccessKey(v)
 			if err != nil {
@@ -318,20 +346,20 @@ func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Creation completed
-	logger.Infof("Creation of Account completed: %s", object.User)
+	logFor(m).Infof("Creation of Account completed: %s", object.User)
 	return resourceAccountRead(d, m)
 }
 
 func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Account update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Account update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewAccount(client)
 	object.ID = d.Id()
-	err := createUpateGetAccountData(d, object)
+	err := createUpateGetAccountData(d, m, object)
 	if err != nil {
 		return err
 	}
@@ -351,7 +379,7 @@ func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating Account attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	// Deal with Set member
@@ -429,8 +457,8 @@ func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
 	if d.HasChange("access_key") {
 		old, new := d.GetChange("access_key")
 		// Remove the old access keys
-		m := old.(*schema.Set).List()
-		for _, v := range m {
+		keys := old.(*schema.Set).List()
+		for _, v := range keys {
 			id := v.(map[string]interface{})["id"].(string)
 			keyid := v.(map[string]interface{})["access_key_id"].(string)
 			if id != "" {
@@ -438,13 +466,13 @@ func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
 				if err != nil {
 					return fmt.Errorf(" Error deleting access key %s : %v", keyid, err)
 				}
-				logger.Debugf("Deleted old key: %+v", keyid)
+				logFor(m).Debugf("Deleted old key: %+v", keyid)
 			}
 		}
 
 		// Add the new access keys
-		m = new.(*schema.Set).List()
-		for _, v := range m {
+		keys = new.(*schema.Set).List()
+		for _, v := range keys {
 			keyid := v.(map[string]interface{})["access_key_id"].(string)
 			secretkey := v.(map[string]interface{})["secret_access_key"].(string)
 			key := vault.AccessKey{}
@@ -455,20 +483,20 @@ func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
 				if err != nil {
 					return fmt.Errorf(" Error adding access key %s : %v", keyid, err)
 				}
-				logger.Debugf("Added new key: %+v", keyid)
+				logFor(m).Debugf("Added new key: %+v", keyid)
 			}
 		}
 	}
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Account completed: %s", object.Name)
+	logFor(m).Infof("Updating of Account completed: %s", object.Name)
 	return resourceAccountRead(d, m)
 }
 
 func resourceAccountDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Account: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Account: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewAccount(client)
 	object.ID = d.Id()
@@ -497,11 +525,11 @@ func resourceAccountDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Account completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Account completed: %s", ResourceIDString(d))
 	return nil
 }
 
-func createUpateGetAccountData(d *schema.ResourceData, object *vault.Account) error {
+func createUpateGetAccountData(d *schema.ResourceData, m interface{}, object *vault.Account) error {
 	object.User = d.Get("name").(string)
 	if v, ok := d.GetOk("credential_type"); ok {
 		object.CredentialType = v.(string)
@@ -596,7 +624,7 @@ func createUpateGetAccountData(d *schema.ResourceData, object *vault.Account) er
 	// Perform validations
 	if object.ID == "" {
 		if err := object.ValidateCredentialType(); err != nil {
-			logger.Errorf("there is error: %s", err)
+			logFor(m).Errorf("there is error: %s", err)
 			return fmt.Errorf(" Schema setting error: %s", err)
 		}
 	}
@@ -0,0 +1,253 @@
+package centrify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/marcozj/golang-sdk/enum/keypairtype"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func resourceAccountCheckout() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAccountCheckoutCreate,
+		Read:   resourceAccountCheckoutRead,
+		Update: resourceAccountCheckoutUpdate,
+		Delete: resourceAccountCheckoutDelete,
+
+		CustomizeDiff: resourceAccountCheckoutCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the account whose credential is checked out",
+			},
+			"access_key_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "AWS access key id. Required for AWS access key account",
+			},
+			"key_pair_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      keypairtype.PrivateKey.String(),
+				ValidateFunc: validation.StringInSlice([]string{keypairtype.PublicKey.String(), keypairtype.PrivateKey.String(), keypairtype.PuTTY.String()}, false),
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Passphrase to use for encrypting the PrivateKey",
+			},
+			"renew_before": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "Replace the checkout once it expires within this many minutes",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"credential_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Either Password, SshKey or AwsAccessKey",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Checked out password of the account",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "SSH private key",
+			},
+			"secret_access_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "AWS secret access key",
+			},
+			"checkout_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the password checkout, which is checked in on destroy",
+			},
+			"lifetime": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Checkout lifetime (minutes). 0 if it is unknown",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time when the checkout expires, in RFC 3339 format. Empty if lifetime is unknown",
+			},
+			"ready_for_renewal": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the checkout expires within renew_before minutes and is replaced on next apply",
+			},
+		},
+	}
+}
+
+func resourceAccountCheckoutRead(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Reading account checkout: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
+
+	// Checkout is gone together with its account
+	object := vault.NewAccount(client)
+	object.ID = d.Get("account_id").(string)
+	err := object.Read()
+	if err != nil {
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
+		return fmt.Errorf(" Error reading account %s: %v", object.ID, err)
+	}
+
+	d.Set("ready_for_renewal", checkoutNeedsRenewal(d.Get("expires_at").(string), d.Get("renew_before").(int), time.Now()))
+
+	logFor(m).Infof("Completed reading account checkout: %s", ResourceIDString(d))
+	return nil
+}
+
+func resourceAccountCheckoutCreate(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Beginning account checkout: %s", d.Get("account_id").(string))
+	client := m.(*providerMeta).client
+
+	object := vault.NewAccount(client)
+	object.ID = d.Get("account_id").(string)
+	if err := object.Read(); err != nil {
+		return fmt.Errorf(" Error reading account %s: %v", object.ID, err)
+	}
+
+	checkedOut := time.Now()
+	coid, lifetime, err := checkoutAccountCredential(client, object, d)
+	if err != nil {
+		return fmt.Errorf(" Error checking out credential of account %s: %v", object.ID, err)
+	}
+
+	// SSH key and AWS access key aren't checked out so the account ID identifies them
+	if coid != "" {
+		d.SetId(coid)
+	} else {
+		d.SetId(object.ID)
+	}
+	d.Set("credential_type", object.CredentialType)
+	d.Set("checkout_id", coid)
+
+	if coid != "" && lifetime <= 0 {
+		// Tenant didn't return lifetime of the checkout
+		lifetime, err = checkoutPolicyLifetime(client, object)
+		if err != nil {
+			return err
+		}
+	}
+	if renewBefore := d.Get("renew_before").(int); lifetime > 0 && renewBefore >= lifetime {
+		return fmt.Errorf(" renew_before (%d minutes) must be less than checkout lifetime of account %s (%d minutes)", renewBefore, object.ID, lifetime)
+	}
+	d.Set("lifetime", lifetime)
+	if lifetime > 0 {
+		d.Set("expires_at", checkedOut.Add(time.Duration(lifetime)*time.Minute).UTC().Format(time.RFC3339))
+	} else {
+		logFor(m).Infof("Checkout lifetime of account %s is unknown, checkout isn't renewed", object.ID)
+		d.Set("expires_at", "")
+	}
+
+	logFor(m).Infof("Account checkout completed: %s", d.Id())
+	return resourceAccountCheckoutRead(d, m)
+}
+
+func resourceAccountCheckoutUpdate(d *schema.ResourceData, m interface{}) error {
+	// Only renew_before can change in place, which needs nothing but reading again
+	return resourceAccountCheckoutRead(d, m)
+}
+
+func resourceAccountCheckoutDelete(d *schema.ResourceData, m interface{}) error {
+	logFor(m).Infof("Beginning check in of account checkout: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
+
+	if coid := d.Get("checkout_id").(string); coid != "" {
+		object := vault.NewAccount(client)
+		object.ID = d.Get("account_id").(string)
+		if _, err := object.CheckinPassword(coid); err != nil {
+			// Checkout that expired, or was checked in by someone else, is already gone
+			if !isNotFound(err) {
+				return fmt.Errorf(" Error checking in password of account %s: %v", object.ID, err)
+			}
+			logFor(m).Infof("Checkout %s no longer exists: %v", coid, err)
+		}
+	}
+
+	d.SetId("")
+	logFor(m).Infof("Check in of account checkout completed")
+	return nil
+}
+
+// resourceAccountCheckoutCustomizeDiff checks that checkout isn't renewed right away, and plans replacement of checkout
+// that Read found near expiry
+func resourceAccountCheckoutCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.HasChange("account_id") {
+		// Lifetime of new checkout is checked by create
+		return nil
+	}
+	if lifetime, renewBefore := d.Get("lifetime").(int), d.Get("renew_before").(int); lifetime > 0 && renewBefore >= lifetime {
+		return fmt.Errorf(" renew_before (%d minutes) must be less than checkout lifetime (%d minutes)", renewBefore, lifetime)
+	}
+	if !d.Get("ready_for_renewal").(bool) {
+		return nil
+	}
+	if err := d.SetNew("ready_for_renewal", false); err != nil {
+		return err
+	}
+	return d.ForceNew("ready_for_renewal")
+}
+
+// checkoutPolicyLifetime returns checkout lifetime (minutes) set by account, or else by its domain or database. It returns
+// 0 if they don't set one, as lifetime set by system or tenant policy can't be read.
+func checkoutPolicyLifetime(client *restapi.RestClient, object *vault.Account) (int, error) {
+	if object.DefaultCheckoutTime > 0 {
+		return object.DefaultCheckoutTime, nil
+	}
+	switch {
+	case object.DomainID != "":
+		domain := vault.NewDomain(client)
+		domain.ID = object.DomainID
+		if err := domain.Read(); err != nil {
+			return 0, fmt.Errorf(" Error reading domain %s: %v", domain.ID, err)
+		}
+		return domain.DefaultCheckoutTime, nil
+	case object.DatabaseID != "":
+		database := vault.NewDatabase(client)
+		database.ID = object.DatabaseID
+		if err := database.Read(); err != nil {
+			return 0, fmt.Errorf(" Error reading database %s: %v", database.ID, err)
+		}
+		return database.DefaultCheckoutTime, nil
+	}
+	return 0, nil
+}
+
+// checkoutNeedsRenewal reports whether checkout expiring at expiresAt expires within renewBefore minutes of now.
+// Checkout without expiry isn't renewed, and checkout with unreadable expiry is.
+func checkoutNeedsRenewal(expiresAt string, renewBefore int, now time.Time) bool {
+	if expiresAt == "" {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return true
+	}
+	return !now.Add(time.Duration(renewBefore) * time.Minute).Before(expiry)
+}
@@ -0,0 +1,156 @@
+package centrify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestResourceAccountCheckout(t *testing.T) {
+	server, meta := testMockMeta(t)
+	defer server.Close()
+
+	account := server.Insert("VaultAccount", map[string]interface{}{"User": "svc_web", "CredentialType": "Password", "Password": "s3cret", "DefaultCheckoutTime": 30})
+
+	r := resourceAccountCheckout()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"account_id": account})
+	if err := r.Create(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := d.Get("password"); got != "s3cret" {
+		t.Errorf("expected password s3cret, got %v", got)
+	}
+	if got := d.Get("checkout_id"); got == "" || got != d.Id() {
+		t.Errorf("expected checkout_id to be set and used as ID, got %v and %s", got, d.Id())
+	}
+	if got := d.Get("lifetime"); got != 30 {
+		t.Errorf("expected lifetime 30, got %v", got)
+	}
+	expiry, err := time.Parse(time.RFC3339, d.Get("expires_at").(string))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if remaining := time.Until(expiry); remaining < 29*time.Minute || remaining > 30*time.Minute {
+		t.Errorf("expected checkout to expire in 30 minutes, got %v", remaining)
+	}
+	if d.Get("ready_for_renewal").(bool) {
+		t.Errorf("expected new checkout not to be ready for renewal")
+	}
+	if n := len(server.Rows("Checkouts")); n != 1 {
+		t.Fatalf("expected 1 checkout, got %d", n)
+	}
+
+	// Checkout near expiry is marked for renewal by refresh
+	d.Set("expires_at", time.Now().Add(5*time.Minute).UTC().Format(time.RFC3339))
+	if err := r.Read(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !d.Get("ready_for_renewal").(bool) {
+		t.Errorf("expected checkout expiring in 5 minutes to be ready for renewal")
+	}
+
+	if err := r.Delete(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(server.Rows("Checkouts")); n != 0 {
+		t.Errorf("expected checkout to be checked in, got %d checkouts", n)
+	}
+
+	// Checkout whose lifetime is unknown has no expiry and isn't renewed
+	unknown := server.Insert("VaultAccount", map[string]interface{}{"User": "svc_db", "CredentialType": "Password", "Password": "s3cret"})
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"account_id": unknown})
+	if err := r.Create(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d.Get("lifetime") != 0 || d.Get("expires_at") != "" || d.Get("ready_for_renewal").(bool) {
+		t.Errorf("expected checkout without lifetime and expiry, got lifetime %v, expires_at %q and ready_for_renewal %v",
+			d.Get("lifetime"), d.Get("expires_at"), d.Get("ready_for_renewal"))
+	}
+
+	// Checkout that would be renewed right away fails
+	short := server.Insert("VaultAccount", map[string]interface{}{"User": "svc_app", "CredentialType": "Password", "Password": "s3cret", "DefaultCheckoutTime": 10})
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"account_id": short})
+	if err := r.Create(d, meta); err == nil || !strings.Contains(err.Error(), "renew_before") {
+		t.Errorf("expected renew_before not less than lifetime to fail, got %v", err)
+	}
+
+	// Checkout that is already checked in doesn't fail destroy
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"account_id": account})
+	d.SetId("gone")
+	d.Set("checkout_id", "gone")
+	if err := r.Delete(d, meta); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestResourceAccountCheckoutRenewal(t *testing.T) {
+	r := resourceAccountCheckout()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"account_id": "a1"})
+	for _, ready := range []string{"false", "true"} {
+		state := &terraform.InstanceState{
+			ID: "co1",
+			Attributes: map[string]string{
+				"id":                "co1",
+				"account_id":        "a1",
+				"key_pair_type":     "PrivateKey",
+				"renew_before":      "10",
+				"checkout_id":       "co1",
+				"ready_for_renewal": ready,
+			},
+		}
+		diff, err := r.Diff(state, config, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := diff != nil && diff.RequiresNew(); got != (ready == "true") {
+			t.Errorf("ready_for_renewal %s: expected replacement %v, got %v", ready, ready == "true", got)
+		}
+	}
+}
+
+func TestResourceAccountCheckoutRenewBefore(t *testing.T) {
+	r := resourceAccountCheckout()
+	state := &terraform.InstanceState{
+		ID: "co1",
+		Attributes: map[string]string{
+			"id":                "co1",
+			"account_id":        "a1",
+			"key_pair_type":     "PrivateKey",
+			"renew_before":      "10",
+			"checkout_id":       "co1",
+			"lifetime":          "30",
+			"ready_for_renewal": "false",
+		},
+	}
+	for renewBefore, valid := range map[int]bool{29: true, 30: false} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"account_id": "a1", "renew_before": renewBefore})
+		if _, err := r.Diff(state, config, nil); (err == nil) != valid {
+			t.Errorf("renew_before %d with lifetime 30: expected valid %v, got %v", renewBefore, valid, err)
+		}
+	}
+}
+
+func TestCheckoutNeedsRenewal(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		expiresAt   string
+		renewBefore int
+		expected    bool
+	}{
+		{"2021-06-01T13:00:00Z", 10, false},
+		{"2021-06-01T12:10:00Z", 10, true},
+		{"2021-06-01T12:05:00Z", 10, true},
+		{"2021-06-01T12:05:00Z", 0, false},
+		{"2021-06-01T11:00:00Z", 0, true},
+		{"", 10, false},
+		{"soon", 10, true},
+	}
+	for _, c := range cases {
+		if got := checkoutNeedsRenewal(c.expiresAt, c.renewBefore, now); got != c.expected {
+			t.Errorf("%s with renew_before %d: expected %v, got %v", c.expiresAt, c.renewBefore, c.expected, got)
+		}
+	}
+}
@@ -2,12 +2,10 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/cloudprovidertype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +18,7 @@ func resourceCloudProvider_deprecated() *schema.Resource {
 		Delete: resourceCloudProviderDelete,
 		Exists: resourceCloudProviderExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceCloudProviderImportID, "name", "cloud_account_id"),
 		},
 
 		Schema:             getCloudProviderSchema(),
@@ -36,7 +34,7 @@ func resourceCloudProvider() *schema.Resource {
 		Delete: resourceCloudProviderDelete,
 		Exists: resourceCloudProviderExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceCloudProviderImportID, "name", "cloud_account_id"),
 		},
 
 		Schema: getCloudProviderSchema(),
@@ -111,27 +109,34 @@ func getCloudProviderSchema() map[string]*schema.Schema {
 }
 
 func resourceCloudProviderExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking CloudProvider exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking CloudProvider exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewCloudProvider(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("CloudProvider exists in tenant: %s", object.ID)
+	logFor(m).Infof("CloudProvider exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceCloudProviderImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewCloudProvider(client)
+	object.Name = keys["name"]
+	object.CloudAccountID = keys["cloud_account_id"]
+	return queryResultID(object.Query())
+}
+
 func resourceCloudProviderRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading CloudProvider: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading CloudProvider: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a System object and populate ID attribute
 	object := vault.NewCloudProvider(client)
@@ -141,7 +146,9 @@ func resourceCloudProviderRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading System: %v", err)
 	}
 	//logger.Debugf("System from tenant: %v", object)
@@ -150,7 +157,7 @@ func resourceCloudProviderRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceCloudProviderRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceCloudProviderRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -160,17 +167,17 @@ func resourceCloudProviderRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading CloudProvider: %s", object.Name)
+	logFor(m).Infof("Completed reading CloudProvider: %s", object.Name)
 	return nil
 }
 
 func resourceCloudProviderCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning CloudProvider creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning CloudProvider creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a CloudProvider object and populate all attributes
 	object := vault.NewCloudProvider(client)
@@ -192,10 +199,15 @@ func resourceCloudProviderCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update CloudProvider login profile
 	// Create API call doesn't set CloudProvider login profile so need to run update again
 	if object.LoginDefaultProfile != "" {
-		logger.Debugf("Update login profile for CloudProvider creation: %s", ResourceIDString(d))
+		logFor(m).Debugf("Update login profile for CloudProvider creation: %s", ResourceIDString(d))
 		resp, err := object.Update()
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating System attribute: %v", err)
@@ -220,17 +232,17 @@ func resourceCloudProviderCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of CloudProvider completed: %s", object.Name)
+	logFor(m).Infof("Creation of CloudProvider completed: %s", object.Name)
 	return resourceCloudProviderRead(d, m)
 }
 
 func resourceCloudProviderUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning CloudProvider update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning CloudProvider update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewCloudProvider(client)
 
 	object.ID = d.Id()
@@ -309,13 +321,13 @@ func resourceCloudProviderUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of CloudProvider completed: %s", object.Name)
+	logFor(m).Infof("Updating of CloudProvider completed: %s", object.Name)
 	return resourceCloudProviderRead(d, m)
 }
 
 func resourceCloudProviderDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of CloudProvider: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of CloudProvider: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewCloudProvider(client)
 	object.ID = d.Id()
@@ -331,7 +343,7 @@ func resourceCloudProviderDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of CloudProvider completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of CloudProvider completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -3,11 +3,11 @@ package centrify
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/databaseclass"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +20,7 @@ func resourceDatabase_deprecated() *schema.Resource {
 		Delete: resourceDatabaseDelete,
 		Exists: resourceDatabaseExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDatabaseImportID, "name", "fqdn"),
 		},
 
 		Schema:             getDatabaseSchema(),
@@ -36,10 +36,15 @@ func resourceDatabase() *schema.Resource {
 		Delete: resourceDatabaseDelete,
 		Exists: resourceDatabaseExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDatabaseImportID, "name", "fqdn"),
 		},
 
 		Schema: getDatabaseSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -170,27 +175,34 @@ func getDatabaseSchema() map[string]*schema.Schema {
 }
 
 func resourceDatabaseExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Database exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Database exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDatabase(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Database exists in tenant: %s", object.ID)
+	logFor(m).Infof("Database exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceDatabaseImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewDatabase(client)
+	object.Name = keys["name"]
+	object.FQDN = keys["fqdn"]
+	return queryResultID(object.Query())
+}
+
 func resourceDatabaseRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Database: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Database: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a Database object and populate ID attribute
 	object := vault.NewDatabase(client)
@@ -200,7 +212,9 @@ func resourceDatabaseRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Database: %v", err)
 	}
 	//logger.Debugf("Database from tenant: %v", object)
@@ -209,7 +223,7 @@ func resourceDatabaseRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceDatabaseRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceDatabaseRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		if k == "connector_list" {
 			// Convert "value1,value1" to schema.TypeSet
@@ -219,17 +233,17 @@ func resourceDatabaseRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Database: %s", object.Name)
+	logFor(m).Infof("Completed reading Database: %s", object.Name)
 	return nil
 }
 
 func resourceDatabaseCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Database creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Database creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a Database object and populate all attributes
 	object := vault.NewDatabase(client)
@@ -251,6 +265,11 @@ func resourceDatabaseCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update Database login profile
 	// Create API call doesn't set Database login profile so need to run update again
 	resp2, err2 := object.Update()
@@ -276,17 +295,17 @@ func resourceDatabaseCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Database completed: %s", object.Name)
+	logFor(m).Infof("Creation of Database completed: %s", object.Name)
 	return resourceDatabaseRead(d, m)
 }
 
 func resourceDatabaseUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Database update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Database update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewDatabase(client)
 
 	object.ID = d.Id()
@@ -304,7 +323,7 @@ func resourceDatabaseUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating Database attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
 	// Deal with Set member
@@ -363,13 +382,13 @@ func resourceDatabaseUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of Database completed: %s", object.Name)
+	logFor(m).Infof("Updating of Database completed: %s", object.Name)
 	return resourceDatabaseRead(d, m)
 }
 
 func resourceDatabaseDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Database: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Database: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDatabase(client)
 	object.ID = d.Id()
@@ -385,7 +404,7 @@ func resourceDatabaseDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Database completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Database completed: %s", ResourceIDString(d))
 	return nil
 }
 
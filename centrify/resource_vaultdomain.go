@@ -3,10 +3,10 @@ package centrify
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -19,10 +19,18 @@ func resourceDomain_deprecated() *schema.Resource {
 		Delete: resourceDomainDelete,
 		Exists: resourceDomainExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDomainImportID, "name"),
 		},
 
-		Schema:             getDomainSchema(),
+		Schema:        getDomainSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceDomainV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDomainStateUpgradeV0,
+			},
+		},
 		DeprecationMessage: "resource centrifyvault_vaultdomain is deprecated will be removed in the future, use centrify_domain instead",
 	}
 }
@@ -35,11 +43,53 @@ func resourceDomain() *schema.Resource {
 		Delete: resourceDomainDelete,
 		Exists: resourceDomainExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceDomainImportID, "name"),
+		},
+
+		Schema:        getDomainSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceDomainV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDomainStateUpgradeV0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
+	}
+}
+
+// domainRenamedAttributes maps attributes renamed in 0.1.3 to their current names
+var domainRenamedAttributes = map[string]string{
+	"enable_zone_role_cleanup":   "enable_zonerole_cleanup",
+	"zone_role_cleanup_interval": "zonerole_cleanup_interval",
+}
 
-		Schema: getDomainSchema(),
+// resourceDomainV0 is domain schema before version 1, with zone role cleanup attributes named as before 0.1.3
+func resourceDomainV0() *schema.Resource {
+	s := getDomainSchema()
+	for old, current := range domainRenamedAttributes {
+		s[old] = s[current]
+		delete(s, current)
 	}
+	return &schema.Resource{Schema: s}
+}
+
+// resourceDomainStateUpgradeV0 renames zone role cleanup attributes of state written before 0.1.3
+func resourceDomainStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	for old, current := range domainRenamedAttributes {
+		if v, ok := rawState[old]; ok {
+			if _, exists := rawState[current]; !exists || rawState[current] == nil {
+				rawState[current] = v
+			}
+			delete(rawState, old)
+		}
+	}
+	return rawState, nil
 }
 
 func getDomainSchema() map[string]*schema.Schema {
@@ -214,27 +264,33 @@ func getDomainSchema() map[string]*schema.Schema {
 }
 
 func resourceDomainExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Domain exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Domain exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDomain(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Domain exists in tenant: %s", object.ID)
+	logFor(m).Infof("Domain exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceDomainImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewDomain(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Domain: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Domain: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate ID attribute
 	object := vault.NewDomain(client)
@@ -244,7 +300,9 @@ func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Domain: %v", err)
 	}
 	//logger.Debugf("Domain from tenant: %v", object)
@@ -253,7 +311,7 @@ func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceDomainRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceDomainRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		if k == "connector_list" {
 			// Convert "value1,value1" to schema.TypeSet
@@ -263,17 +321,17 @@ func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Domain: %s", object.Name)
+	logFor(m).Infof("Completed reading Domain: %s", object.Name)
 	return nil
 }
 
 func resourceDomainCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate all attributes
 	object := vault.NewDomain(client)
@@ -298,6 +356,11 @@ func resourceDomainCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Get the rest of attributes
 	err = createUpateGetDomainData(d, object)
 	if err != nil {
@@ -336,17 +399,17 @@ func resourceDomainCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Domain completed: %s", object.Name)
+	logFor(m).Infof("Creation of Domain completed: %s", object.Name)
 	return resourceDomainRead(d, m)
 }
 
 func resourceDomainUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewDomain(client)
 
 	object.ID = d.Id()
@@ -432,13 +495,13 @@ func resourceDomainUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of Domain completed: %s", object.Name)
+	logFor(m).Infof("Updating of Domain completed: %s", object.Name)
 	return resourceDomainRead(d, m)
 }
 
 func resourceDomainDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Domain: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Domain: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDomain(client)
 	object.ID = d.Id()
@@ -454,7 +517,7 @@ func resourceDomainDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Domain completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Domain completed: %s", ResourceIDString(d))
 	return nil
 }
 
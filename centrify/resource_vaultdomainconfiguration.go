@@ -2,11 +2,11 @@ package centrify
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func resourceDomainConfiguration_deprecated() *schema.Resource {
@@ -16,10 +16,18 @@ func resourceDomainConfiguration_deprecated() *schema.Resource {
 		Update: resourceDomainConfigurationUpdate,
 		Delete: resourceDomainConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceDomainConfigurationImport,
 		},
 
-		Schema:             getDomainConfigurationSchema(),
+		Schema:        getDomainConfigurationSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceDomainConfigurationV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDomainConfigurationStateUpgradeV0,
+			},
+		},
 		DeprecationMessage: "resource centrifyvault_vaultdomainconfiguration is deprecated will be removed in the future, use centrify_domainconfiguration instead",
 	}
 }
@@ -31,11 +39,44 @@ func resourceDomainConfiguration() *schema.Resource {
 		Update: resourceDomainConfigurationUpdate,
 		Delete: resourceDomainConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceDomainConfigurationImport,
+		},
+
+		Schema:        getDomainConfigurationSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceDomainConfigurationV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDomainConfigurationStateUpgradeV0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
+	}
+}
+
+// resourceDomainConfigurationV0 is domain configuration schema before version 1, which has the same attributes
+func resourceDomainConfigurationV0() *schema.Resource {
+	return &schema.Resource{Schema: getDomainConfigurationSchema()}
+}
 
-		Schema: getDomainConfigurationSchema(),
+// resourceDomainConfigurationStateUpgradeV0 normalizes ID of state written by domain reconciliation resource,
+// <domain ID>-reconciliation, or by earlier imports, <domain ID>, to <domain ID>-configuration and populates domain_id
+func resourceDomainConfigurationStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	id, _ := rawState["id"].(string)
+	domainID, _ := rawState["domain_id"].(string)
+	if domainID == "" {
+		domainID = strings.TrimSuffix(strings.TrimSuffix(id, "-configuration"), "-reconciliation")
+		rawState["domain_id"] = domainID
 	}
+	if domainID != "" {
+		rawState["id"] = fmt.Sprintf("%s-configuration", domainID)
+	}
+	return rawState, nil
 }
 
 func getDomainConfigurationSchema() map[string]*schema.Schema {
@@ -113,9 +154,23 @@ func getDomainConfigurationSchema() map[string]*schema.Schema {
 	}
 }
 
+// resourceDomainConfigurationImport accepts ID of the domain configuration, ID of the domain or name of the domain
+// and populates domain_id that is used to read the configuration
+func resourceDomainConfigurationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	results, err := importStateByName(resourceDomainImportID, "name")(d, m)
+	if err != nil {
+		return nil, err
+	}
+	domainID := strings.TrimSuffix(d.Id(), "-configuration")
+	d.Set("domain_id", domainID)
+	d.SetId(fmt.Sprintf("%s-configuration", domainID))
+
+	return results, nil
+}
+
 func resourceDomainConfigurationRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Domain Configuration: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Domain Configuration: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate ID attribute
 	object := vault.NewDomain(client)
@@ -125,7 +180,9 @@ func resourceDomainConfigurationRead(d *schema.ResourceData, m interface{}) erro
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Domain: %v", err)
 	}
 	//logger.Debugf("Domain from tenant: %v", object)
@@ -154,17 +211,17 @@ func resourceDomainConfigurationRead(d *schema.ResourceData, m interface{}) erro
 		d.Set("assigned_zonerole", zrschema)
 	}
 
-	logger.Infof("Completed reading Domain Configuration: %s", object.Name)
+	logFor(m).Infof("Completed reading Domain Configuration: %s", object.Name)
 	return nil
 }
 
 func resourceDomainConfigurationCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain Configuration: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain Configuration: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate all attributes
 	object := vault.NewDomain(client)
@@ -199,17 +256,17 @@ func resourceDomainConfigurationCreate(d *schema.ResourceData, m interface{}) er
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Setting of Domain Configuration completed: %s", object.Name)
+	logFor(m).Infof("Setting of Domain Configuration completed: %s", object.Name)
 	return resourceDomainConfigurationRead(d, m)
 }
 
 func resourceDomainConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain Configuration update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain Configuration update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewDomain(client)
 	object.ID = d.Get("domain_id").(string)
 	err := object.Read()
@@ -242,13 +299,13 @@ func resourceDomainConfigurationUpdate(d *schema.ResourceData, m interface{}) er
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of Domain Configuration completed: %s", object.Name)
+	logFor(m).Infof("Updating of Domain Configuration completed: %s", object.Name)
 	return resourceDomainConfigurationRead(d, m)
 }
 
 func resourceDomainConfigurationDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning removing of Domain Configuration: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning removing of Domain Configuration: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDomain(client)
 	object.ID = d.Get("domain_id").(string)
@@ -282,7 +339,7 @@ func resourceDomainConfigurationDelete(d *schema.ResourceData, m interface{}) er
 
 	d.SetId("")
 
-	logger.Infof("Removing of Domain Configuration completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Removing of Domain Configuration completed: %s", ResourceIDString(d))
 	return nil
 }
 
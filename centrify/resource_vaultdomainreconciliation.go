@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
-	"github.com/marcozj/golang-sdk/restapi"
 )
 
 func resourceDomainReconciliation() *schema.Resource {
@@ -84,8 +82,8 @@ func resourceDomainReconciliation() *schema.Resource {
 }
 
 func resourceDomainReconciliationRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Domain reconciliation settings: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Domain reconciliation settings: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate ID attribute
 	object := vault.NewDomain(client)
@@ -95,7 +93,9 @@ func resourceDomainReconciliationRead(d *schema.ResourceData, m interface{}) err
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Domain: %v", err)
 	}
 	//logger.Debugf("Domain from tenant: %v", object)
@@ -110,17 +110,17 @@ func resourceDomainReconciliationRead(d *schema.ResourceData, m interface{}) err
 	d.Set("provisioning_admin_id", object.ProvisioningAdminID)
 	d.Set("reconciliation_account_name", object.ReconciliationAccountName)
 
-	logger.Infof("Completed reading Domain reconciliation settings: %s", object.Name)
+	logFor(m).Infof("Completed reading Domain reconciliation settings: %s", object.Name)
 	return nil
 }
 
 func resourceDomainReconciliationCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain reconciliation settings: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain reconciliation settings: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a Domain object and populate all attributes
 	object := vault.NewDomain(client)
@@ -155,17 +155,17 @@ func resourceDomainReconciliationCreate(d *schema.ResourceData, m interface{}) e
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Setting of Domain reconciliation completed: %s", object.Name)
+	logFor(m).Infof("Setting of Domain reconciliation completed: %s", object.Name)
 	return resourceDomainReconciliationRead(d, m)
 }
 
 func resourceDomainReconciliationUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Domain reconciliation update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Domain reconciliation update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewDomain(client)
 	object.ID = d.Get("domain_id").(string)
 	err := object.Read()
@@ -198,13 +198,13 @@ func resourceDomainReconciliationUpdate(d *schema.ResourceData, m interface{}) e
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of Domain reconciliation completed: %s", object.Name)
+	logFor(m).Infof("Updating of Domain reconciliation completed: %s", object.Name)
 	return resourceDomainReconciliationRead(d, m)
 }
 
 func resourceDomainReconciliationDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning removing of Domain reconciliation settings: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning removing of Domain reconciliation settings: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewDomain(client)
 	object.ID = d.Get("domain_id").(string)
@@ -235,7 +235,7 @@ func resourceDomainReconciliationDelete(d *schema.ResourceData, m interface{}) e
 
 	d.SetId("")
 
-	logger.Infof("Removing of Domain reconciliation setting completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Removing of Domain reconciliation setting completed: %s", ResourceIDString(d))
 	return nil
 }
 
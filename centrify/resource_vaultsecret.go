@@ -2,12 +2,10 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/secrettype"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -20,7 +18,7 @@ func resourceSecret_deprecated() *schema.Resource {
 		Delete: resourceSecretDelete,
 		Exists: resourceSecretExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSecretImportID, "path"),
 		},
 
 		Schema:             getSecretSchema(),
@@ -36,7 +34,7 @@ func resourceSecret() *schema.Resource {
 		Delete: resourceSecretDelete,
 		Exists: resourceSecretExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSecretImportID, "path"),
 		},
 
 		Schema: getSecretSchema(),
@@ -107,31 +105,38 @@ func getSecretSchema() map[string]*schema.Schema {
 		},
 		"permission":     getPermissionSchema(),
 		"challenge_rule": getChallengeRulesSchema(),
+		"adopt_existing": getAdoptExistingSchema(),
 	}
 }
 
 func resourceSecretExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Secret exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Secret exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSecret(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Secret exists in tenant: %s", object.ID)
+	logFor(m).Infof("Secret exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceSecretImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewSecret(client)
+	object.ParentPath, object.SecretName = splitImportPath(keys["path"])
+	return object.GetIDByName()
+}
+
 func resourceSecretRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Secret: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Secret: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewSecret object and populate ID attribute
 	object := vault.NewSecret(client)
@@ -141,7 +146,9 @@ func resourceSecretRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading Secret: %v", err)
 	}
 	//logger.Debugf("Secret from tenant: %+v", object)
@@ -149,7 +156,7 @@ func resourceSecretRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceSecretRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceSecretRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -161,17 +168,26 @@ func resourceSecretRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Secret: %s", object.Name)
+	logFor(m).Infof("Completed reading Secret: %s", object.Name)
 	return nil
 }
 
 func resourceSecretCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Secret creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Secret creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
+
+	if d.Get("adopt_existing").(bool) {
+		existing := vault.NewSecret(client)
+		existing.SecretName = d.Get("secret_name").(string)
+		existing.ParentPath = d.Get("parent_path").(string)
+		if adopted, err := adoptExisting(d, m, "Secret", existing.GetIDByName, resourceSecretUpdate); adopted || err != nil {
+			return err
+		}
+	}
 
 	// Create a Secret object and populate all attributes
 	object := vault.NewSecret(client)
@@ -192,6 +208,11 @@ func resourceSecretCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update password checkout profile
 	// Create API call doesn't set challenge profile so need to run update again
 	err = getUpateGetSecretData(d, object)
@@ -223,17 +244,17 @@ func resourceSecretCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Secret completed: %s", object.SecretName)
+	logFor(m).Infof("Creation of Secret completed: %s", object.SecretName)
 	return resourceSecretRead(d, m)
 }
 
 func resourceSecretUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Secret update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Secret update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewSecret(client)
 	object.ID = d.Id()
 	err := getUpateGetSecretData(d, object)
@@ -253,7 +274,7 @@ func resourceSecretUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating Secret attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -312,13 +333,13 @@ func resourceSecretUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Secret completed: %s", object.Name)
+	logFor(m).Infof("Updating of Secret completed: %s", object.Name)
 	return resourceSecretRead(d, m)
 }
 
 func resourceSecretDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Secret: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Secret: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSecret(client)
 	object.ID = d.Id()
@@ -349,7 +370,7 @@ func resourceSecretDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Secret completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Secret completed: %s", ResourceIDString(d))
 	return nil
 }
 
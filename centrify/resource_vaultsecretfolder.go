@@ -2,10 +2,8 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -18,7 +16,7 @@ func resourceSecretFolder_deprecated() *schema.Resource {
 		Delete: resourceSecretFolderDelete,
 		Exists: resourceSecretFolderExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSecretFolderImportID, "path"),
 		},
 
 		Schema:             getSecretFolderSchema(),
@@ -34,7 +32,7 @@ func resourceSecretFolder() *schema.Resource {
 		Delete: resourceSecretFolderDelete,
 		Exists: resourceSecretFolderExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSecretFolderImportID, "path"),
 		},
 
 		Schema: getSecretFolderSchema(),
@@ -76,27 +74,33 @@ func getSecretFolderSchema() map[string]*schema.Schema {
 }
 
 func resourceSecretFolderExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking SecretFolder exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking SecretFolder exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSecretFolder(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("SecretFolder exists in tenant: %s", object.ID)
+	logFor(m).Infof("SecretFolder exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceSecretFolderImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewSecretFolder(client)
+	object.ParentPath, object.Name = splitImportPath(keys["path"])
+	return object.GetIDByName()
+}
+
 func resourceSecretFolderRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading SecretFolder: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading SecretFolder: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewSecretFolder object and populate ID attribute
 	object := vault.NewSecretFolder(client)
@@ -106,7 +110,9 @@ func resourceSecretFolderRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading SecretFolder: %v", err)
 	}
 	//logger.Debugf("SecretFolder from tenant: %+v", object)
@@ -114,22 +120,22 @@ func resourceSecretFolderRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceSecretFolderRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceSecretFolderRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		d.Set(k, v)
 	}
 
-	logger.Infof("Completed reading SecretFolder: %s", object.Name)
+	logFor(m).Infof("Completed reading SecretFolder: %s", object.Name)
 	return nil
 }
 
 func resourceSecretFolderCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SecretFolder creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SecretFolder creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a SecretFolder object and populate all attributes
 	object := vault.NewSecretFolder(client)
@@ -150,6 +156,11 @@ func resourceSecretFolderCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update SecretFolder login profile
 	// Create API call doesn't set SecretFolder login profile so need to run update again
 	err = getUpdateSecretFolderData(d, object)
@@ -182,17 +193,17 @@ func resourceSecretFolderCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of SecretFolder completed: %s", object.Name)
+	logFor(m).Infof("Creation of SecretFolder completed: %s", object.Name)
 	return resourceSecretFolderRead(d, m)
 }
 
 func resourceSecretFolderUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SecretFolder update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SecretFolder update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewSecretFolder(client)
 	object.ID = d.Id()
 	err := getUpdateSecretFolderData(d, object)
@@ -210,7 +221,7 @@ func resourceSecretFolderUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating SecretFolder attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("parent_id") {
@@ -284,13 +295,13 @@ func resourceSecretFolderUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of SecretFolder completed: %s", object.Name)
+	logFor(m).Infof("Updating of SecretFolder completed: %s", object.Name)
 	return resourceSecretFolderRead(d, m)
 }
 
 func resourceSecretFolderDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of SecretFolder: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of SecretFolder: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSecretFolder(client)
 	object.ID = d.Id()
@@ -321,7 +332,7 @@ func resourceSecretFolderDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of SecretFolder completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of SecretFolder completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -3,12 +3,12 @@ package centrify
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/computerclass"
 	"github.com/marcozj/golang-sdk/enum/managementmode"
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -21,7 +21,7 @@ func resourceSystem_deprecated() *schema.Resource {
 		Delete: resourceSystemDelete,
 		Exists: resourceSystemExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSystemImportID, "name", "fqdn", "computer_class"),
 		},
 
 		Schema:             getSystemSchema(),
@@ -37,10 +37,15 @@ func resourceSystem() *schema.Resource {
 		Delete: resourceSystemDelete,
 		Exists: resourceSystemExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSystemImportID, "name", "fqdn", "computer_class"),
 		},
 
 		Schema: getSystemSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -341,31 +346,40 @@ func getSystemSchema() map[string]*schema.Schema {
 		"permission":               getPermissionSchema(),
 		"challenge_rule":           getChallengeRulesSchema(),
 		"privilege_elevation_rule": getChallengeRulesSchema(),
+		"adopt_existing":           getAdoptExistingSchema(),
 	}
 }
 
 func resourceSystemExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking System exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking System exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSystem(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("System exists in tenant: %s", object.ID)
+	logFor(m).Infof("System exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceSystemImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewSystem(client)
+	object.Name = keys["name"]
+	object.FQDN = keys["fqdn"]
+	object.ComputerClass = keys["computer_class"]
+	return queryResultID(object.Query())
+}
+
 func resourceSystemRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading System: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading System: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a System object and populate ID attribute
 	object := vault.NewSystem(client)
@@ -375,7 +389,9 @@ func resourceSystemRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf(" Error reading System: %v", err)
 	}
 	//logger.Debugf("System from tenant: %v", object)
@@ -384,7 +400,7 @@ func resourceSystemRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceSystemRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceSystemRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "connector_list":
@@ -415,17 +431,26 @@ func resourceSystemRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading System: %s", object.Name)
+	logFor(m).Infof("Completed reading System: %s", object.Name)
 	return nil
 }
 
 func resourceSystemCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning System creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning System creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
+
+	if d.Get("adopt_existing").(bool) {
+		existing := vault.NewSystem(client)
+		existing.Name = d.Get("name").(string)
+		existing.ComputerClass = d.Get("computer_class").(string)
+		if adopted, err := adoptExisting(d, m, "System", existing.GetIDByName, resourceSystemUpdate); adopted || err != nil {
+			return err
+		}
+	}
 
 	// Create a System object and populate all attributes
 	object := vault.NewSystem(client)
@@ -447,10 +472,15 @@ func resourceSystemCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// 2nd step to update system login profile and connectors
 	// Create API call doesn't set system login profile and connectors so need to run update again
 	if object.LoginDefaultProfile != "" || object.ProxyCollectionList != "" {
-		logger.Debugf("Update login profile and connector for System creation: %s", ResourceIDString(d))
+		logFor(m).Debugf("Update login profile and connector for System creation: %s", ResourceIDString(d))
 		resp, err := object.Update()
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating System attribute: %v", err)
@@ -475,17 +505,17 @@ func resourceSystemCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of System completed: %s", object.Name)
+	logFor(m).Infof("Creation of System completed: %s", object.Name)
 	return resourceSystemRead(d, m)
 }
 
 func resourceSystemUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning System update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning System update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewSystem(client)
 
 	object.ID = d.Id()
@@ -516,7 +546,7 @@ func resourceSystemUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf(" Error updating System attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %+v", object)
+		logFor(m).Debugf("Updated attributes to: %+v", object)
 	}
 
 	// Deal with Set member
@@ -575,13 +605,13 @@ func resourceSystemUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.Partial(false)
-	logger.Infof("Updating of System completed: %s", object.Name)
+	logFor(m).Infof("Updating of System completed: %s", object.Name)
 	return resourceSystemRead(d, m)
 }
 
 func resourceSystemDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of System: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of System: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSystem(client)
 	object.ID = d.Id()
@@ -597,7 +627,7 @@ func resourceSystemDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of System completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of System completed: %s", ResourceIDString(d))
 	return nil
 }
 
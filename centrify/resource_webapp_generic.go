@@ -2,14 +2,12 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/webapp/accountmapping"
 	"github.com/marcozj/golang-sdk/enum/webapp/generic/applicationtemplate"
 
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -22,7 +20,7 @@ func resourceGenericWebApp_deprecated() *schema.Resource {
 		Delete: resourceGenericWebAppDelete,
 		Exists: resourceGenericWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceGenericWebAppImportID, "name"),
 		},
 
 		Schema:             getGenericWebAppSchema(),
@@ -38,7 +36,7 @@ func resourceGenericWebApp() *schema.Resource {
 		Delete: resourceGenericWebAppDelete,
 		Exists: resourceGenericWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceGenericWebAppImportID, "name"),
 		},
 
 		Schema: getGenericWebAppSchema(),
@@ -207,27 +205,33 @@ func getGenericWebAppSchema() map[string]*schema.Schema {
 }
 
 func resourceGenericWebAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Generic WebApp exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Generic WebApp exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewGenericWebApp(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Generic WebApp exists in tenant: %s", object.ID)
+	logFor(m).Infof("Generic WebApp exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceGenericWebAppImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewGenericWebApp(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceGenericWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Generic WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Generic WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewWebpp object and populate ID attribute
 	object := vault.NewGenericWebApp(client)
@@ -237,7 +241,9 @@ func resourceGenericWebAppRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading Generic WebApp: %v", err)
 	}
 	//logger.Debugf("WebApp from tenant: %+v", object)
@@ -245,7 +251,7 @@ func resourceGenericWebAppRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceGenericWebAppRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceGenericWebAppRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -274,17 +280,17 @@ func resourceGenericWebAppRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Generic WebApp: %s", object.Name)
+	logFor(m).Infof("Completed reading Generic WebApp: %s", object.Name)
 	return nil
 }
 
 func resourceGenericWebAppCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Generic WebApp creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Generic WebApp creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a WebApp object
 	object := vault.NewGenericWebApp(client)
@@ -306,6 +312,11 @@ func resourceGenericWebAppCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Update attributes to complete creation
 	err = createUpateGetGenericWebAppData(d, object)
 	if err != nil {
@@ -334,17 +345,17 @@ func resourceGenericWebAppCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Generic WebApp completed: %s", object.Name)
+	logFor(m).Infof("Creation of Generic WebApp completed: %s", object.Name)
 	return resourceGenericWebAppRead(d, m)
 }
 
 func resourceGenericWebAppUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Generic WebApp update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Generic WebApp update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewGenericWebApp(client)
 	object.ID = d.Id()
 
@@ -366,7 +377,7 @@ func resourceGenericWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf("error updating Generic WebApp attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -425,13 +436,13 @@ func resourceGenericWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Generic WebApp completed: %s", object.Name)
+	logFor(m).Infof("Updating of Generic WebApp completed: %s", object.Name)
 	return resourceGenericWebAppRead(d, m)
 }
 
 func resourceGenericWebAppDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Generic WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Generic WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewGenericWebApp(client)
 	object.ID = d.Id()
@@ -447,7 +458,7 @@ func resourceGenericWebAppDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Generic WebApp completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Generic WebApp completed: %s", ResourceIDString(d))
 	return nil
 }
 
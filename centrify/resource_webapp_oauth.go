@@ -10,7 +10,6 @@ import (
 	"github.com/marcozj/golang-sdk/enum/webapp/oauth/clientidtype"
 	"github.com/marcozj/golang-sdk/enum/webapp/oauth/tokentype"
 
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -23,7 +22,7 @@ func resourceOauthWebApp_deprecated() *schema.Resource {
 		Delete: resourceOauthWebAppDelete,
 		Exists: resourceOauthWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceOauthWebAppImportID, "name", "application_id"),
 		},
 
 		Schema:             getOauthWebAppSchema(),
@@ -39,7 +38,7 @@ func resourceOauthWebApp() *schema.Resource {
 		Delete: resourceOauthWebAppDelete,
 		Exists: resourceOauthWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceOauthWebAppImportID, "name", "application_id"),
 		},
 
 		Schema: getOauthWebAppSchema(),
@@ -229,27 +228,34 @@ func getOauthWebAppSchema() map[string]*schema.Schema {
 }
 
 func resourceOauthWebAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Oauth WebApp exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Oauth WebApp exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewOauthWebApp(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Oauth WebApp exists in tenant: %s", object.ID)
+	logFor(m).Infof("Oauth WebApp exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceOauthWebAppImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewOauthWebApp(client)
+	object.Name = keys["name"]
+	object.ApplicationID = keys["application_id"]
+	return queryResultID(object.Query())
+}
+
 func resourceOauthWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Oauth WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Oauth WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewWebpp object and populate ID attribute
 	object := vault.NewOauthWebApp(client)
@@ -259,7 +265,9 @@ func resourceOauthWebAppRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading Oauth WebApp: %v", err)
 	}
 	//logger.Debugf("WebApp from tenant: %+v", object)
@@ -267,7 +275,7 @@ func resourceOauthWebAppRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceOauthWebAppRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceOauthWebAppRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "oauth_profile":
@@ -288,17 +296,17 @@ func resourceOauthWebAppRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Oauth WebApp: %s", object.Name)
+	logFor(m).Infof("Completed reading Oauth WebApp: %s", object.Name)
 	return nil
 }
 
 func resourceOauthWebAppCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Oauth WebApp creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Oauth WebApp creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a WebApp object
 	object := vault.NewOauthWebApp(client)
@@ -320,6 +328,11 @@ func resourceOauthWebAppCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Update attributes to complete creation
 	err = createUpateGetOauthWebAppData(d, object)
 	if err != nil {
@@ -348,17 +361,17 @@ func resourceOauthWebAppCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Oauth WebApp completed: %s", object.Name)
+	logFor(m).Infof("Creation of Oauth WebApp completed: %s", object.Name)
 	return resourceOauthWebAppRead(d, m)
 }
 
 func resourceOauthWebAppUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Oauth WebApp update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Oauth WebApp update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewOauthWebApp(client)
 	object.ID = d.Id()
 	err := createUpateGetOauthWebAppData(d, object)
@@ -372,7 +385,7 @@ func resourceOauthWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf("error updating Oauth WebApp attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -431,13 +444,13 @@ func resourceOauthWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Oauth WebApp completed: %s", object.Name)
+	logFor(m).Infof("Updating of Oauth WebApp completed: %s", object.Name)
 	return resourceOauthWebAppRead(d, m)
 }
 
 func resourceOauthWebAppDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Oauth WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Oauth WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewOauthWebApp(client)
 	object.ID = d.Id()
@@ -453,7 +466,7 @@ func resourceOauthWebAppDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Oauth WebApp completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Oauth WebApp completed: %s", ResourceIDString(d))
 	return nil
 }
 
@@ -2,14 +2,12 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/marcozj/golang-sdk/enum/webapp/accountmapping"
 	"github.com/marcozj/golang-sdk/enum/webapp/oidc/applicationtemplate"
 
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -22,7 +20,7 @@ func resourceOidcWebApp_deprecated() *schema.Resource {
 		Delete: resourceOidcWebAppDelete,
 		Exists: resourceOidcWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceOidcWebAppImportID, "name", "application_id"),
 		},
 
 		Schema:             getOidcWebAppSchema(),
@@ -38,7 +36,7 @@ func resourceOidcWebApp() *schema.Resource {
 		Delete: resourceOidcWebAppDelete,
 		Exists: resourceOidcWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceOidcWebAppImportID, "name", "application_id"),
 		},
 
 		Schema: getOidcWebAppSchema(),
@@ -214,27 +212,34 @@ func getOidcWebAppSchema() map[string]*schema.Schema {
 }
 
 func resourceOidcWebAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking Oidc WebApp exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking Oidc WebApp exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewOidcWebApp(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("Oidc WebApp exists in tenant: %s", object.ID)
+	logFor(m).Infof("Oidc WebApp exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceOidcWebAppImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewOidcWebApp(client)
+	object.Name = keys["name"]
+	object.ApplicationID = keys["application_id"]
+	return queryResultID(object.Query())
+}
+
 func resourceOidcWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading Oidc WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading Oidc WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewWebpp object and populate ID attribute
 	object := vault.NewOidcWebApp(client)
@@ -244,7 +249,9 @@ func resourceOidcWebAppRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading Oidc WebApp: %v", err)
 	}
 	//logger.Debugf("WebApp from tenant: %+v", object)
@@ -252,7 +259,7 @@ func resourceOidcWebAppRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceOidcWebAppRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceOidcWebAppRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "oauth_profile":
@@ -273,17 +280,17 @@ func resourceOidcWebAppRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading Oidc WebApp: %s", object.Name)
+	logFor(m).Infof("Completed reading Oidc WebApp: %s", object.Name)
 	return nil
 }
 
 func resourceOidcWebAppCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Oidc WebApp creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Oidc WebApp creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a WebApp object
 	object := vault.NewOidcWebApp(client)
@@ -305,6 +312,11 @@ func resourceOidcWebAppCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Update attributes to complete creation
 	err = createUpateGetOidcWebAppData(d, object)
 	if err != nil {
@@ -333,17 +345,17 @@ func resourceOidcWebAppCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of Oidc WebApp completed: %s", object.Name)
+	logFor(m).Infof("Creation of Oidc WebApp completed: %s", object.Name)
 	return resourceOidcWebAppRead(d, m)
 }
 
 func resourceOidcWebAppUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning Oidc WebApp update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning Oidc WebApp update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewOidcWebApp(client)
 	object.ID = d.Id()
 	// ClientId is gnerated value and must be supplied for update action,
@@ -374,7 +386,7 @@ func resourceOidcWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf("error updating Oauth WebApp attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -433,13 +445,13 @@ func resourceOidcWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of Oidc WebApp completed: %s", object.Name)
+	logFor(m).Infof("Updating of Oidc WebApp completed: %s", object.Name)
 	return resourceOidcWebAppRead(d, m)
 }
 
 func resourceOidcWebAppDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of Oidc WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of Oidc WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewOidcWebApp(client)
 	object.ID = d.Id()
@@ -455,7 +467,7 @@ func resourceOidcWebAppDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of Oidc WebApp completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of Oidc WebApp completed: %s", ResourceIDString(d))
 	return nil
 }
 
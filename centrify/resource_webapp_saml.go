@@ -2,7 +2,6 @@ package centrify
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -10,7 +9,6 @@ import (
 	"github.com/marcozj/golang-sdk/enum/webapp/saml/applicationtemplate"
 	"github.com/marcozj/golang-sdk/enum/webapp/saml/configurationmethod"
 
-	logger "github.com/marcozj/golang-sdk/logging"
 	vault "github.com/marcozj/golang-sdk/platform"
 	"github.com/marcozj/golang-sdk/restapi"
 )
@@ -23,7 +21,7 @@ func resourceSamlWebApp_deprecated() *schema.Resource {
 		Delete: resourceSamlWebAppDelete,
 		Exists: resourceSamlWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSamlWebAppImportID, "name"),
 		},
 
 		Schema:             getSamlWebAppSchema(),
@@ -39,7 +37,7 @@ func resourceSamlWebApp() *schema.Resource {
 		Delete: resourceSamlWebAppDelete,
 		Exists: resourceSamlWebAppExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByName(resourceSamlWebAppImportID, "name"),
 		},
 
 		Schema: getSamlWebAppSchema(),
@@ -318,27 +316,33 @@ func samlAttributeSuppress(k, old, new string, d *schema.ResourceData) bool {
 }
 
 func resourceSamlWebAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
-	logger.Infof("Checking SAML WebApp exist: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Checking SAML WebApp exist: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSamlWebApp(client)
 	object.ID = d.Id()
 	err := object.Read()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
 
-	logger.Infof("SAML WebApp exists in tenant: %s", object.ID)
+	logFor(m).Infof("SAML WebApp exists in tenant: %s", object.ID)
 	return true, nil
 }
 
+func resourceSamlWebAppImportID(client *restapi.RestClient, keys importKeys) (string, error) {
+	object := vault.NewSamlWebApp(client)
+	object.Name = keys["name"]
+	return object.GetIDByName()
+}
+
 func resourceSamlWebAppRead(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Reading SAML WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Reading SAML WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	// Create a NewWebpp object and populate ID attribute
 	object := vault.NewSamlWebApp(client)
@@ -348,7 +352,9 @@ func resourceSamlWebAppRead(d *schema.ResourceData, m interface{}) error {
 	// If the resource does not exist, inform Terraform. We want to immediately
 	// return here to prevent further processing.
 	if err != nil {
-		d.SetId("")
+		if removeIfNotFound(d, m, err) {
+			return nil
+		}
 		return fmt.Errorf("error reading SAML WebApp: %v", err)
 	}
 	//logger.Debugf("WebApp from tenant: %+v", object)
@@ -356,7 +362,7 @@ func resourceSamlWebAppRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Generated Map for resourceSamlWebAppRead(): %+v", schemamap)
+	logFor(m).Debugf("Generated Map for resourceSamlWebAppRead(): %+v", schemamap)
 	for k, v := range schemamap {
 		switch k {
 		case "challenge_rule":
@@ -375,17 +381,17 @@ func resourceSamlWebAppRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	logger.Infof("Completed reading SAML WebApp: %s", object.Name)
+	logFor(m).Infof("Completed reading SAML WebApp: %s", object.Name)
 	return nil
 }
 
 func resourceSamlWebAppCreate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SAML WebApp creation: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SAML WebApp creation: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 
 	// Create a WebApp object
 	object := vault.NewSamlWebApp(client)
@@ -407,6 +413,11 @@ func resourceSamlWebAppCreate(d *schema.ResourceData, m interface{}) error {
 	// Need to populate ID attribute for subsequence processes
 	object.ID = id
 
+	// Tenant may not return the new object right away, wait for it before making further changes
+	if err := waitForCreated(d, m); err != nil {
+		return err
+	}
+
 	// Update attributes to complete creation
 	err = createUpateGetSamlWebAppData(d, object)
 	if err != nil {
@@ -435,17 +446,17 @@ func resourceSamlWebAppCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Creation completed
 	d.Partial(false)
-	logger.Infof("Creation of SAML WebApp completed: %s", object.Name)
+	logFor(m).Infof("Creation of SAML WebApp completed: %s", object.Name)
 	return resourceSamlWebAppRead(d, m)
 }
 
 func resourceSamlWebAppUpdate(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning SAML WebApp update: %s", ResourceIDString(d))
+	logFor(m).Infof("Beginning SAML WebApp update: %s", ResourceIDString(d))
 
 	// Enable partial state mode
 	d.Partial(true)
 
-	client := m.(*restapi.RestClient)
+	client := m.(*providerMeta).client
 	object := vault.NewSamlWebApp(client)
 	object.ID = d.Id()
 	err := createUpateGetSamlWebAppData(d, object)
@@ -462,7 +473,7 @@ func resourceSamlWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 		if err != nil || !resp.Success {
 			return fmt.Errorf("error updating SAML WebApp attribute: %v", err)
 		}
-		logger.Debugf("Updated attributes to: %v", object)
+		logFor(m).Debugf("Updated attributes to: %v", object)
 	}
 
 	if d.HasChange("sets") {
@@ -521,13 +532,13 @@ func resourceSamlWebAppUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// We succeeded, disable partial mode. This causes Terraform to save all fields again.
 	d.Partial(false)
-	logger.Infof("Updating of WebApp completed: %s", object.Name)
+	logFor(m).Infof("Updating of WebApp completed: %s", object.Name)
 	return resourceSamlWebAppRead(d, m)
 }
 
 func resourceSamlWebAppDelete(d *schema.ResourceData, m interface{}) error {
-	logger.Infof("Beginning deletion of SAML WebApp: %s", ResourceIDString(d))
-	client := m.(*restapi.RestClient)
+	logFor(m).Infof("Beginning deletion of SAML WebApp: %s", ResourceIDString(d))
+	client := m.(*providerMeta).client
 
 	object := vault.NewSamlWebApp(client)
 	object.ID = d.Id()
@@ -543,7 +554,7 @@ func resourceSamlWebAppDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	}
 
-	logger.Infof("Deletion of SAML WebApp completed: %s", ResourceIDString(d))
+	logFor(m).Infof("Deletion of SAML WebApp completed: %s", ResourceIDString(d))
 	return nil
 }
 
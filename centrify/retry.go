@@ -0,0 +1,165 @@
+package centrify
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// idempotentPrefixes are API method name prefixes that are safe to resend after a transient failure
+// because they only read from the tenant
+var idempotentPrefixes = []string{"get", "query", "read", "retrieve"}
+
+// retryTransport - http.RoundTripper that retries throttled and transient API failures with jittered exponential backoff
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, minWait, maxWait time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+}
+
+// RoundTrip sends the request and resends it when the response or error is retryable
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	idempotent := isIdempotentRequest(req)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(withRequestBody(req, body))
+		if attempt >= t.maxRetries || !shouldRetry(resp, err, idempotent) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			requestLogger(req).Infof("Retrying %s in %v (attempt %d of %d): %v", req.URL.Path, wait, attempt+1, t.maxRetries, err)
+		} else {
+			requestLogger(req).Infof("Retrying %s in %v (attempt %d of %d): HTTP %d", req.URL.Path, wait, attempt+1, t.maxRetries, resp.StatusCode)
+			// Drain body so that the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt. Retry-After header takes precedence if present.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				wait = t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.minWait
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Equal jitter so that concurrent resources don't retry in lock step
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// shouldRetry decides whether a request should be resent. Throttled requests are never processed by the tenant
+// so they are always retried. Other transient failures are retried for idempotent requests only.
+func shouldRetry(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// isIdempotentRequest reports whether API request only reads data. All Centrify APIs are POST so it is
+// determined by API method name, for example /UserMgmt/GetUserAttributes or /RedRock/query
+func isIdempotentRequest(req *http.Request) bool {
+	method := strings.ToLower(path.Base(req.URL.Path))
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses Retry-After header value which is either delay in seconds or HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// readRequestBody reads and closes request body so that the request can be sent multiple times
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
+
+// withRequestBody returns a copy of request with the given body
+func withRequestBody(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	if body != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+	}
+	return r
+}
@@ -0,0 +1,103 @@
+package centrify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryServer(failures int, status int, retryAfter string) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	return server, &calls
+}
+
+func testRetryPost(t *testing.T, tr http.RoundTripper, url string) *http.Response {
+	client := &http.Client{Transport: tr}
+	resp, err := client.Post(url, "application/json", strings.NewReader(`{"ID":"1"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestRetryTransport_throttled(t *testing.T) {
+	server, calls := testRetryServer(2, http.StatusTooManyRequests, "0")
+	defer server.Close()
+
+	tr := newRetryTransport(nil, 3, time.Millisecond, 10*time.Millisecond)
+	resp := testRetryPost(t, tr, server.URL+"/CDirectoryService/CreateUser")
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryTransport_badGateway(t *testing.T) {
+	server, calls := testRetryServer(1, http.StatusBadGateway, "")
+	defer server.Close()
+
+	tr := newRetryTransport(nil, 3, time.Millisecond, 10*time.Millisecond)
+	resp := testRetryPost(t, tr, server.URL+"/UserMgmt/GetUserAttributes")
+	if resp.StatusCode != http.StatusOK || *calls != 2 {
+		t.Errorf("Expected read to succeed after 2 calls, got status %d after %d calls", resp.StatusCode, *calls)
+	}
+
+	// Non-idempotent call must not be resent
+	*calls = 0
+	resp = testRetryPost(t, tr, server.URL+"/CDirectoryService/CreateUser")
+	if resp.StatusCode != http.StatusBadGateway || *calls != 1 {
+		t.Errorf("Expected create to fail after 1 call, got status %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransport_maxRetries(t *testing.T) {
+	server, calls := testRetryServer(10, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	tr := newRetryTransport(nil, 2, time.Millisecond, time.Millisecond)
+	resp := testRetryPost(t, tr, server.URL+"/RedRock/query")
+	if resp.StatusCode != http.StatusServiceUnavailable || *calls != 3 {
+		t.Errorf("Expected status 503 after 3 calls, got status %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("5"); !ok || wait != 5*time.Second {
+		t.Errorf("Expected 5s, got %v", wait)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("Expected wait up to 1m, got %v", wait)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Expected invalid value to be ignored")
+	}
+}
+
+func TestRetryTransport_retryAfterCapped(t *testing.T) {
+	tr := newRetryTransport(http.DefaultTransport, 1, time.Second, 2*time.Second)
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if wait := tr.backoff(0, resp); wait != 2*time.Second {
+		t.Errorf("Expected Retry-After to be capped at 2s, got %v", wait)
+	}
+	resp.Header.Set("Retry-After", "1")
+	if wait := tr.backoff(0, resp); wait != time.Second {
+		t.Errorf("Expected Retry-After of 1s, got %v", wait)
+	}
+}
@@ -0,0 +1,42 @@
+package centrify
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// withRollback wraps create function so that object created by a create that fails in a later step, for example
+// when adding it to sets or setting its permissions, is deleted again if rollback_on_failed_create is enabled.
+// Otherwise the object would be left in tenant and the next apply would fail on duplicate name.
+func withRollback(resourceType string, create, del func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if create == nil || del == nil {
+		return create
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		err := create(d, m)
+		if err == nil || d.Id() == "" {
+			return err
+		}
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return fmt.Errorf("%v. Rollback of partially created object %s isn't possible with provider meta %T", err, d.Id(), m)
+		}
+		if !meta.rollbackOnFailedCreate {
+			return err
+		}
+
+		id := d.Id()
+		log := operationLogger{tags: logTags{resourceType: resourceType, operation: "create", d: d}}
+		log.Infof("Rolling back partially created object %s: %v", id, err)
+		if derr := del(d, m); derr != nil {
+			// Keep the object in state so that Terraform taints it and deletes it on next apply
+			d.SetId(id)
+			return fmt.Errorf("%v. Rollback of partially created object %s failed: %v", err, id, derr)
+		}
+		d.SetId("")
+		log.Infof("Partially created object %s is deleted", id)
+
+		return err
+	}
+}
@@ -0,0 +1,77 @@
+package centrify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
+)
+
+func testSecretCreateInMissingSet(t *testing.T, server *mocktenant.Server, rollback bool) (*schema.ResourceData, error) {
+	config := testMockTokenConfig(server)
+	config.RollbackOnFailedCreate = rollback
+	meta := testMockClient(t, config)
+
+	r := Provider().ResourcesMap["centrify_secret"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"secret_name": "rollback",
+		"secret_text": "s3cr3t",
+		"type":        "Text",
+		"sets":        []interface{}{"00000000-0000-0000-0000-000000000000"},
+	})
+	d.MarkNewResource()
+	return d, r.Create(d, meta)
+}
+
+func TestRollbackOnFailedCreate(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	d, err := testSecretCreateInMissingSet(t, server, true)
+	if err == nil {
+		t.Fatal("Expected error adding secret to missing set")
+	}
+	if strings.Contains(err.Error(), "Rollback") {
+		t.Errorf("Expected original error to be reported, got %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected rolled back secret to be removed from state, ID is %s", d.Id())
+	}
+	if rows := server.Rows("DataVault"); len(rows) != 0 {
+		t.Errorf("Expected partially created secret to be deleted, found %v", rows)
+	}
+}
+
+func TestRollbackOnFailedCreate_disabled(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+
+	d, err := testSecretCreateInMissingSet(t, server, false)
+	if err == nil {
+		t.Fatal("Expected error adding secret to missing set")
+	}
+	if d.Id() == "" {
+		t.Error("Expected partially created secret to stay in state so that it is tainted")
+	}
+	if rows := server.Rows("DataVault"); len(rows) != 1 {
+		t.Errorf("Expected partially created secret to be kept, found %d secrets", len(rows))
+	}
+}
+
+func TestWithRollback_unexpectedMeta(t *testing.T) {
+	create := func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("1234")
+		return fmt.Errorf("failed to add to set")
+	}
+	del := func(d *schema.ResourceData, m interface{}) error {
+		t.Error("Expected no rollback without provider meta")
+		return nil
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	err := withRollback("centrify_secret", create, del)(d, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to add to set") || !strings.Contains(err.Error(), "isn't possible") {
+		t.Errorf("Expected original error and rollback error, got %v", err)
+	}
+}
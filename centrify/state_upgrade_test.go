@@ -0,0 +1,93 @@
+package centrify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceGroupMappingStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":         groupMappingsID,
+		"bulkupdate": true,
+		"mapping": []interface{}{
+			map[string]interface{}{"attribute_value": "Sales", "group_name": "Sales Team"},
+			map[string]interface{}{"attribute_value": "IT", "group_name": "IT Team"},
+		},
+	}
+	expected := map[string]interface{}{
+		"id":         groupMappingsID,
+		"bulkupdate": true,
+		"mapping": map[string]interface{}{
+			"Sales": "Sales Team",
+			"IT":    "IT Team",
+		},
+	}
+
+	actual, err := resourceGroupMappingStateUpgradeV0(rawState, nil)
+	if err != nil {
+		t.Fatalf("error upgrading state: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceDomainStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":                         "d1",
+		"name":                       "example.com",
+		"enable_zone_role_cleanup":   false,
+		"zone_role_cleanup_interval": 12,
+	}
+	expected := map[string]interface{}{
+		"id":                        "d1",
+		"name":                      "example.com",
+		"enable_zonerole_cleanup":   false,
+		"zonerole_cleanup_interval": 12,
+	}
+
+	actual, err := resourceDomainStateUpgradeV0(rawState, nil)
+	if err != nil {
+		t.Fatalf("error upgrading state: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for k := range actual {
+		if _, ok := resourceDomain().Schema[k]; !ok && k != "id" {
+			t.Errorf("upgraded state has attribute %s that isn't in schema", k)
+		}
+	}
+}
+
+func TestResourceDomainConfigurationStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name     string
+		rawState map[string]interface{}
+		id       string
+		domainID string
+	}{
+		{"reconciliation", map[string]interface{}{"id": "d1-reconciliation", "domain_id": "d1"}, "d1-configuration", "d1"},
+		{"imported by domain ID", map[string]interface{}{"id": "d1"}, "d1-configuration", "d1"},
+		{"missing domain_id", map[string]interface{}{"id": "d1-configuration", "domain_id": ""}, "d1-configuration", "d1"},
+		{"current", map[string]interface{}{"id": "d1-configuration", "domain_id": "d1"}, "d1-configuration", "d1"},
+	}
+
+	for _, c := range cases {
+		actual, err := resourceDomainConfigurationStateUpgradeV0(c.rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: error upgrading state: %v", c.name, err)
+		}
+		if actual["id"] != c.id || actual["domain_id"] != c.domainID {
+			t.Errorf("%s: expected id %s and domain_id %s, got %v and %v", c.name, c.id, c.domainID, actual["id"], actual["domain_id"])
+		}
+	}
+}
+
+func TestStateUpgradersAreComplete(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if len(r.StateUpgraders) != r.SchemaVersion {
+			t.Errorf("%s: schema version %d has %d state upgraders", name, r.SchemaVersion, len(r.StateUpgraders))
+		}
+	}
+}
@@ -272,6 +272,11 @@ func StringSliceToInterface(s []string) []interface{} {
 	return i
 }
 
+// hasPrefixFold reports whether s begins with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
 func expandRoleMembers(v interface{}) []vault.RoleMember {
 	members := []vault.RoleMember{}
 
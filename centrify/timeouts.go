@@ -0,0 +1,43 @@
+package centrify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// operationTimeout returns timeout of resource operation, or zero if the resource doesn't declare one
+func operationTimeout(r *schema.Resource, d *schema.ResourceData, operation string) time.Duration {
+	if r.Timeouts == nil {
+		return 0
+	}
+	switch operation {
+	case "create":
+		if r.Timeouts.Create != nil {
+			return d.Timeout(schema.TimeoutCreate)
+		}
+	case "update":
+		if r.Timeouts.Update != nil {
+			return d.Timeout(schema.TimeoutUpdate)
+		}
+	case "delete":
+		if r.Timeouts.Delete != nil {
+			return d.Timeout(schema.TimeoutDelete)
+		}
+	}
+	return 0
+}
+
+// timeoutError is returned when resource operation doesn't complete within its timeout
+type timeoutError struct {
+	resourceType string
+	operation    string
+	timeout      time.Duration
+	err          error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf(" %s %s did not complete within %v, increase timeouts.%s if more time is needed: %v",
+		e.resourceType, e.operation, e.timeout, e.operation, e.err)
+}
@@ -0,0 +1,69 @@
+package centrify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+func TestResourceTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	retry := newRetryTransport(http.DefaultTransport, 5, time.Second, time.Minute)
+	meta := &providerMeta{client: &restapi.RestClient{Service: server.URL, Client: &http.Client{Transport: retry}}}
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			resp, err := m.(*providerMeta).client.Client.Post(server.URL+"/ServerManage/AddResource", "application/json", nil)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			return nil
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(100 * time.Millisecond),
+		},
+	}
+	wrapResourceOperations("centrify_system", r)
+
+	start := time.Now()
+	err := r.Create(r.Data(nil), meta)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected API call to be cancelled at the deadline, took %v", elapsed)
+	}
+	if _, ok := err.(*timeoutError); !ok {
+		t.Fatalf("Expected timeout error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "centrify_system create did not complete within 100ms") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestResourceTimeout_notDeclared(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Minute),
+		},
+	}
+	d := r.Data(nil)
+	if timeout := operationTimeout(r, d, "create"); timeout != time.Minute {
+		t.Errorf("Expected create timeout of 1m, got %v", timeout)
+	}
+	if timeout := operationTimeout(r, d, "update"); timeout != 0 {
+		t.Errorf("Expected no update timeout, got %v", timeout)
+	}
+	if timeout := operationTimeout(&schema.Resource{}, d, "delete"); timeout != 0 {
+		t.Errorf("Expected no timeout for resource without timeouts, got %v", timeout)
+	}
+}
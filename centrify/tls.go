@@ -0,0 +1,82 @@
+package centrify
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	logger "github.com/marcozj/golang-sdk/logging"
+)
+
+// tlsConfig builds TLS configuration from CA bundle, client certificate and skip_cert_verify settings
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.SkipCertVerify,
+	}
+
+	caPEM := []byte(c.CACertPEM)
+	if c.CACertFile != "" {
+		var err error
+		caPEM, err = ioutil.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf(" Failed to read ca_cert_file: %v", err)
+		}
+	}
+	if len(caPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			logger.Infof("System certificate pool isn't available, only custom CA certificates are trusted: %v", err)
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf(" No valid PEM encoded certificate found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, fmt.Errorf(" client_cert and client_key must be provided together")
+		}
+		certPEM, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf(" Failed to read client_cert: %v", err)
+		}
+		keyPEM, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf(" Failed to read client_key: %v", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf(" Invalid client_cert or client_key: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// httpClientFactory returns a function that creates HTTP clients honoring TLS settings
+func (c *Config) httpClientFactory() (func() *http.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsConfig
+
+	return func() *http.Client {
+		return &http.Client{Transport: tr}
+	}, nil
+}
+
+// readPEM returns value as is if it is PEM encoded content, otherwise reads it as file path
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
@@ -0,0 +1,202 @@
+package centrify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	vault "github.com/marcozj/golang-sdk/platform"
+	"github.com/marcozj/golang-sdk/restapi"
+)
+
+// Interval between attempts to read a new object. It doubles on every attempt up to the maximum.
+const (
+	visibilityMinInterval = 500 * time.Millisecond
+	visibilityMaxInterval = 5 * time.Second
+)
+
+// visibleObject is an object of golang-sdk platform package that can be read from tenant by its ID
+type visibleObject interface {
+	Read() error
+}
+
+type visibilityProbe func(client *restapi.RestClient, id string) visibleObject
+
+// visibilityProbes return object that reads a new object of the resource type by its ID. Create of these resources
+// waits for the new object to become visible before it makes further API calls.
+var visibilityProbes = map[string]visibilityProbe{
+	"centrify_account": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewAccount(c)
+		o.ID = id
+		return o
+	},
+	"centrify_authenticationprofile": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewAuthenticationProfile(c)
+		o.ID = id
+		return o
+	},
+	"centrify_cloudprovider": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewCloudProvider(c)
+		o.ID = id
+		return o
+	},
+	"centrify_database": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewDatabase(c)
+		o.ID = id
+		return o
+	},
+	"centrify_desktopapp": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewDesktopApp(c)
+		o.ID = id
+		return o
+	},
+	"centrify_domain": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewDomain(c)
+		o.ID = id
+		return o
+	},
+	"centrify_federatedgroup": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewFederatedGroup(c)
+		o.ID = id
+		return o
+	},
+	"centrify_manualset": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewManualSet(c)
+		o.ID = id
+		return o
+	},
+	"centrify_multiplexedaccount": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewMultiplexedAccount(c)
+		o.ID = id
+		return o
+	},
+	"centrify_passwordprofile": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewPasswordProfile(c)
+		o.ID = id
+		return o
+	},
+	"centrify_policy": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewPolicy(c)
+		o.ID = id
+		return o
+	},
+	"centrify_role": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewRole(c)
+		o.ID = id
+		return o
+	},
+	"centrify_secret": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewSecret(c)
+		o.ID = id
+		return o
+	},
+	"centrify_secretfolder": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewSecretFolder(c)
+		o.ID = id
+		return o
+	},
+	"centrify_service": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewService(c)
+		o.ID = id
+		return o
+	},
+	"centrify_sshkey": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewSSHKey(c)
+		o.ID = id
+		return o
+	},
+	"centrify_system": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewSystem(c)
+		o.ID = id
+		return o
+	},
+	"centrify_user": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewUser(c)
+		o.ID = id
+		return o
+	},
+	"centrify_webapp_generic": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewGenericWebApp(c)
+		o.ID = id
+		return o
+	},
+	"centrify_webapp_oauth": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewOauthWebApp(c)
+		o.ID = id
+		return o
+	},
+	"centrify_webapp_oidc": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewOidcWebApp(c)
+		o.ID = id
+		return o
+	},
+	"centrify_webapp_saml": func(c *restapi.RestClient, id string) visibleObject {
+		o := vault.NewSamlWebApp(c)
+		o.ID = id
+		return o
+	},
+}
+
+// visibilityProbeFor returns probe of resource type, including deprecated centrifyvault_* and centrifyvault_vault*
+// names of the resources
+func visibilityProbeFor(resourceType string) (visibilityProbe, bool) {
+	if strings.HasPrefix(resourceType, "centrifyvault_") {
+		resourceType = "centrify_" + strings.TrimPrefix(strings.TrimPrefix(resourceType, "centrifyvault_"), "vault")
+	}
+	probe, ok := visibilityProbes[resourceType]
+	return probe, ok
+}
+
+// waitForCreated waits for object just created by resource create operation, whose ID is set in d, to become
+// visible before further API calls are made to it. Resources without visibility probe don't wait.
+func waitForCreated(d *schema.ResourceData, m interface{}) error {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	probe, ok := visibilityProbeFor(meta.operation.resourceType)
+	if !ok {
+		return nil
+	}
+	if err := waitForVisible(meta, probe(meta.client, d.Id())); err != nil {
+		return fmt.Errorf(" Error waiting for %s %s to become visible: %v", meta.operation.resourceType, d.Id(), err)
+	}
+	return nil
+}
+
+// waitForVisible polls a newly created object until it can be read. Tenant may not have indexed the object yet
+// right after creation, so subsequent update, set membership or permission calls would fail with not found.
+// Wait is bounded by visibility_timeout and by timeout of the resource operation.
+func waitForVisible(m interface{}, object visibleObject) error {
+	timeout := m.(*providerMeta).visibilityTimeout
+	if timeout <= 0 {
+		return nil
+	}
+	deadline := time.Now().Add(timeout)
+	if operation := m.(*providerMeta).operation; !operation.deadline.IsZero() && operation.deadline.Before(deadline) {
+		deadline = operation.deadline
+	}
+
+	interval := visibilityMinInterval
+	for attempt := 1; ; attempt++ {
+		err := object.Read()
+		if err == nil || !isNotFound(err) {
+			return err
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return fmt.Errorf(" Object is not visible after %d attempts: %v", attempt, err)
+		}
+		if wait > interval {
+			wait = interval
+		}
+		logFor(m).Debugf("Object is not visible yet, retrying in %v: %v", wait, err)
+		time.Sleep(wait)
+
+		interval *= 2
+		if interval > visibilityMaxInterval {
+			interval = visibilityMaxInterval
+		}
+	}
+}
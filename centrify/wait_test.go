@@ -0,0 +1,106 @@
+package centrify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/marcozj/terraform-provider-centrify/centrify/internal/mocktenant"
+)
+
+func testSecretCreate(t *testing.T, server *mocktenant.Server, visibilityTimeout time.Duration) (*schema.ResourceData, error) {
+	config := testMockTokenConfig(server)
+	config.VisibilityTimeout = visibilityTimeout
+	meta := testMockClient(t, config)
+
+	r := Provider().ResourcesMap["centrify_secret"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"secret_name": "visibility",
+		"secret_text": "s3cr3t",
+		"type":        "Text",
+	})
+	// Terraform marks resource being created as new
+	d.MarkNewResource()
+	return d, r.Create(d, meta)
+}
+
+func TestWaitForVisible(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	server.SetVisibilityLag(2)
+
+	d, err := testSecretCreate(t, server, 30*time.Second)
+	if err != nil {
+		t.Fatalf("Expected creation to wait for the secret to become visible, got %v", err)
+	}
+	if d.Get("secret_name").(string) != "visibility" {
+		t.Errorf("Expected secret to be read after creation, got %q", d.Get("secret_name"))
+	}
+}
+
+func TestWaitForVisible_timeout(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	server.SetVisibilityLag(100)
+
+	start := time.Now()
+	_, err := testSecretCreate(t, server, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "to become visible") {
+		t.Fatalf("Expected visibility timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected wait to be bounded by visibility timeout, took %v", elapsed)
+	}
+}
+
+func TestWaitForVisible_disabled(t *testing.T) {
+	server := mocktenant.NewServer()
+	defer server.Close()
+	server.SetVisibilityLag(1)
+
+	if _, err := testSecretCreate(t, server, 0); err == nil {
+		t.Fatal("Expected read of not yet visible secret to fail when waiting is disabled")
+	}
+}
+
+type failingObject struct {
+	err   error
+	reads int
+}
+
+func (o *failingObject) Read() error {
+	o.reads++
+	return o.err
+}
+
+func TestWaitForVisible_otherError(t *testing.T) {
+	object := &failingObject{err: fmt.Errorf("401 Unauthorized")}
+	if err := waitForVisible(&providerMeta{visibilityTimeout: time.Minute}, object); err != object.err {
+		t.Errorf("Expected error other than not found to be returned, got %v", err)
+	}
+	if object.reads != 1 {
+		t.Errorf("Expected error other than not found not to be retried, read %d times", object.reads)
+	}
+}
+
+func TestVisibilityProbeFor(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for name := range visibilityProbes {
+		if _, ok := resources[name]; !ok {
+			t.Errorf("Visibility probe is registered for unknown resource %s", name)
+		}
+	}
+	for _, name := range []string{"centrifyvault_vaultsecret", "centrifyvault_user", "centrifyvault_webapp_saml"} {
+		if _, ok := resources[name]; !ok {
+			t.Fatalf("Unknown resource %s", name)
+		}
+		if _, ok := visibilityProbeFor(name); !ok {
+			t.Errorf("Expected deprecated resource %s to wait for new object", name)
+		}
+	}
+	if _, ok := visibilityProbeFor("centrify_role_membership"); ok {
+		t.Error("Expected no visibility probe for centrify_role_membership")
+	}
+}
@@ -1,6 +1,13 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/marcozj/terraform-provider-centrify/centrify"
 
 	"github.com/hashicorp/terraform-plugin-sdk/plugin"
@@ -8,9 +15,129 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate-state" {
+		os.Exit(migrateState(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(export(os.Args[2:]))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() terraform.ResourceProvider {
 			return centrify.Provider()
 		},
 	})
 }
+
+// migrateState implements migrate-state subcommand that rewrites state of deprecated centrifyvault_* resources
+func migrateState(args []string) int {
+	flags := flag.NewFlagSet("migrate-state", flag.ContinueOnError)
+	out := flags.String("out", "", "Write migrated state to this file instead of standard output")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s migrate-state [-out <file>] <state file>\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Rewrites Terraform state, or output of `terraform show -json`, so that deprecated centrifyvault_*\n")
+		fmt.Fprintf(flags.Output(), "resources and data sources use their centrify_* names. Use - to read state from standard input.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var in []byte
+	var err error
+	if flags.Arg(0) == "-" {
+		in, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		in, err = ioutil.ReadFile(flags.Arg(0))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading state: %v\n", err)
+		return 1
+	}
+
+	migration, err := centrify.MigrateState(in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error migrating state:%v\n", err)
+		return 1
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(migration.State)
+	} else {
+		err = ioutil.WriteFile(*out, migration.State, 0600)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing state: %v\n", err)
+		return 1
+	}
+
+	for _, change := range migration.Changes {
+		fmt.Fprintf(os.Stderr, "Migrated %s\n", change)
+	}
+	fmt.Fprintf(os.Stderr, "%d resources migrated\n", len(migration.Changes))
+	return 0
+}
+
+// export implements export subcommand that generates Terraform configuration and import script from existing tenant
+func export(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "Directory to write centrify.tf and import.sh to")
+	types := flags.String("types", "", "Comma separated resource types to export, all supported types if empty")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [-dir <directory>] [-types <type,...>]\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Generates Terraform configuration of centrify_* resources for objects of existing tenant, and\n")
+		fmt.Fprintf(flags.Output(), "import.sh script that imports them into state. Provider is configured with CENTRIFY_* environment\n")
+		fmt.Fprintf(flags.Output(), "variables. Supported types are %s.\n\n", strings.Join(centrify.ExportTypes(), ", "))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return 2
+	}
+
+	configPath := filepath.Join(*dir, "centrify.tf")
+	scriptPath := filepath.Join(*dir, "import.sh")
+	for _, path := range []string{configPath, scriptPath} {
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintf(os.Stderr, "Error: %s already exists\n", path)
+			return 1
+		}
+	}
+
+	provider := centrify.Provider()
+	if err := provider.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{})); err != nil {
+		fmt.Fprintf(os.Stderr, "Error configuring provider: %v\n", err)
+		return 1
+	}
+	var exportTypes []string
+	if *types != "" {
+		exportTypes = strings.Split(*types, ",")
+	}
+	result, err := centrify.Export(provider.Meta(), exportTypes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting tenant:%v\n", err)
+		return 1
+	}
+
+	if err := ioutil.WriteFile(configPath, result.Config, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing configuration: %v\n", err)
+		return 1
+	}
+	if err := ioutil.WriteFile(scriptPath, result.ImportScript, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing import script: %v\n", err)
+		return 1
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	fmt.Fprintf(os.Stderr, "%d resources exported to %s, run %s to import them\n", result.Resources, configPath, scriptPath)
+	return 0
+}